@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/masintxi/gamehub/internal/client"
+	"github.com/masintxi/gamehub/internal/fakesteam"
+)
+
+const demoUser = "76561198000000001"
+
+func TestDemoLoginCapturesMarketSession(t *testing.T) {
+	seed, err := fakesteam.DefaultSeed()
+	if err != nil {
+		t.Fatalf("loading seed: %v", err)
+	}
+	server := httptest.NewServer(fakesteam.New(seed))
+	t.Cleanup(server.Close)
+
+	// Every Steam URL goes to the fake Steam, the way --demo sets it up
+	target, _ := url.Parse(server.URL)
+	jar, _ := cookiejar.New(nil)
+	sa := NewSteamAuth(SteamAuth{
+		ApiKey:        "demo",
+		CallbackURL:   "http://localhost:8080/auth/steam/callback",
+		SteamClient:   &http.Client{Transport: client.Chain(http.DefaultTransport, client.Upstream(target)), Jar: jar},
+		Jar:           jar,
+		LoginEndpoint: server.URL + "/openid/login",
+	})
+
+	// 1. Sign in on the fake Steam, which redirects back to the callback
+	authURL, err := sa.GetAuthURL()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	browser := &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := browser.Get(authURL + "&steamid=" + demoUser)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("expected a redirect, got %d", resp.StatusCode)
+	}
+
+	// 2. Complete the login
+	rec := httptest.NewRecorder()
+	sa.HandleCallback(rec, httptest.NewRequest("GET", resp.Header.Get("Location"), nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var login struct {
+		SteamID         string `json:"steamID"`
+		SessionCaptured bool   `json:"sessionCaptured"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &login); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if login.SteamID != demoUser || !login.SessionCaptured {
+		t.Errorf("expected the market session to be captured, got %s", rec.Body.String())
+	}
+	if sa.SessionID == "" || sa.SteamLoginSecure == "" {
+		t.Errorf("expected the market cookies, got sessionid %q and steamLoginSecure %q", sa.SessionID, sa.SteamLoginSecure)
+	}
+	if got := sa.MarketSteamID(); got != demoUser {
+		t.Errorf("expected the market session of %s, got %q", demoUser, got)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/gorilla/sessions"
+	"github.com/masintxi/gamehub/internal/steam"
 )
 
 type SteamAuth struct {
@@ -21,6 +22,8 @@ type SteamAuth struct {
 	SessionID        string
 	SteamLoginSecure string
 	SteamClient      *http.Client
+	Jar              http.CookieJar
+	LoginEndpoint    string // OpenID provider, Steam's unless running against a fake one
 	//Provider         *steam.Provider
 }
 
@@ -43,15 +46,20 @@ func NewSteamAuth(sa SteamAuth) *SteamAuth {
 	store := sessions.NewCookieStore([]byte("your-secret-key"))
 	sa.Store = store
 
-	// Create a cookie jar
-	jar, err := cookiejar.New(nil)
-	if err != nil {
-		log.Fatalf("Failed to create cookie jar: %v", err)
+	if sa.LoginEndpoint == "" {
+		sa.LoginEndpoint = apiLoginEndpoint
 	}
 
-	// Attach the cookie jar to an HTTP client
-	sa.SteamClient = &http.Client{
-		Jar: jar,
+	// Without a shared client, use one with its own cookie jar
+	if sa.SteamClient == nil {
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			log.Fatalf("Failed to create cookie jar: %v", err)
+		}
+		sa.SteamClient = &http.Client{
+			Jar: jar,
+		}
+		sa.Jar = jar
 	}
 
 	return &sa
@@ -72,7 +80,7 @@ func (sa *SteamAuth) GetAuthURL() (string, error) {
 		"openid.return_to":  callbackURL.String(),
 	}
 
-	u, err := url.Parse(apiLoginEndpoint)
+	u, err := url.Parse(sa.LoginEndpoint)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse login endpoint: %w", err)
 	}
@@ -120,7 +128,7 @@ func (sa *SteamAuth) HandleCallback(w http.ResponseWriter, r *http.Request) {
 	for key, values := range r.URL.Query() {
 		log.Printf("Query Param: %s=%s\n", key, values)
 	}
-	resp, err := sa.SteamClient.PostForm(apiLoginEndpoint, validationParams)
+	resp, err := sa.SteamClient.PostForm(sa.LoginEndpoint, validationParams)
 	if err != nil {
 		http.Error(w, "Error validating OpenID response", http.StatusInternalServerError)
 		return
@@ -158,7 +166,7 @@ func (sa *SteamAuth) HandleCallback(w http.ResponseWriter, r *http.Request) {
 	log.Println("ResponseNonce: ", ResponseNonce)
 
 	steamURL, _ := url.Parse("https://steamcommunity.com")
-	cookies := sa.SteamClient.Jar.Cookies(steamURL)
+	cookies := sa.Jar.Cookies(steamURL)
 	for _, cookie := range cookies {
 		log.Printf("Cookie in jar: %s=%s\n", cookie.Name, cookie.Value)
 		//cookieSession.Values[cookie.Name] = cookie.Value
@@ -180,6 +188,11 @@ func (sa *SteamAuth) HandleCallback(w http.ResponseWriter, r *http.Request) {
 	}
 	cookieSession.Values["name"] = steamUser
 
+	// Initialize market session
+	if err := sa.InitializeMarketSession(); err != nil {
+		log.Printf("Failed to initialize market session: %v", err)
+	}
+
 	if err := cookieSession.Save(r, w); err != nil {
 		log.Printf("Error saving session: %v", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
@@ -189,33 +202,12 @@ func (sa *SteamAuth) HandleCallback(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, `{
         "steamID": "%s",
         "name": "%s",
-        "sessionCaptured": true
-    }`, steamID, steamUser)
+        "sessionCaptured": %t
+    }`, steamID, steamUser, sa.SessionID != "" && sa.SteamLoginSecure != "")
 }
 
 func (sa *SteamAuth) FetchUser(session *sessions.Session) (string, error) {
-	apiResponse := struct {
-		Response struct {
-			Players []struct {
-				Steamid                  string `json:"steamid"`
-				Communityvisibilitystate int    `json:"communityvisibilitystate"`
-				Profilestate             int    `json:"profilestate"`
-				Personaname              string `json:"personaname"`
-				Commentpermission        int    `json:"commentpermission"`
-				Profileurl               string `json:"profileurl"`
-				Avatar                   string `json:"avatar"`
-				Avatarmedium             string `json:"avatarmedium"`
-				Avatarfull               string `json:"avatarfull"`
-				Avatarhash               string `json:"avatarhash"`
-				Lastlogoff               int    `json:"lastlogoff"`
-				Personastate             int    `json:"personastate"`
-				Realname                 string `json:"realname"`
-				Primaryclanid            string `json:"primaryclanid"`
-				Timecreated              int    `json:"timecreated"`
-				Personastateflags        int    `json:"personastateflags"`
-			} `json:"players"`
-		} `json:"response"`
-	}{}
+	var apiResponse steam.PlayerResponse
 
 	steamID, ok := session.Values["steamID"].(string)
 	if !ok {
@@ -228,8 +220,7 @@ func (sa *SteamAuth) FetchUser(session *sessions.Session) (string, error) {
 		return "", err
 	}
 
-	req.Header.Add("Accept", "application/json")
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := sa.SteamClient.Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -280,6 +271,20 @@ func (sa *SteamAuth) SetSteamCookies(sessionID, steamLoginSecure string) {
 	sa.SteamLoginSecure = steamLoginSecure
 }
 
+// MarketSteamID returns the account the market session belongs to. Steam
+// starts the steamLoginSecure cookie with it, as "<steamid>||<token>".
+func (sa *SteamAuth) MarketSteamID() string {
+	value, err := url.QueryUnescape(sa.SteamLoginSecure)
+	if err != nil {
+		return ""
+	}
+	steamID, _, ok := strings.Cut(value, "||")
+	if !ok {
+		return ""
+	}
+	return steamID
+}
+
 func (sa *SteamAuth) GetMarketCookies() map[string]string {
 	return map[string]string{
 		"sessionid":        sa.SessionID,
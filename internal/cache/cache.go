@@ -14,9 +14,10 @@ import (
 )
 
 type cacheEntry struct {
-	CreatedAt time.Time `json:"created_at"`
-	Val       []byte    `json:"val"`
-	Size      int64     `json:"size"`
+	CreatedAt time.Time     `json:"created_at"`
+	Val       []byte        `json:"val"`
+	Size      int64         `json:"size"`
+	TTL       time.Duration `json:"ttl,omitempty"` // Zero uses ExpireAfter
 }
 
 type CacheConfig struct {
@@ -82,6 +83,12 @@ func NewCache(config CacheConfig) *Cache {
 }
 
 func (c *Cache) Add(key string, val []byte) {
+	c.AddWithTTL(key, val, 0)
+}
+
+// AddWithTTL adds an item that expires after ttl instead of ExpireAfter, for
+// data that goes stale quicker than the rest.
+func (c *Cache) AddWithTTL(key string, val []byte, ttl time.Duration) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -117,6 +124,7 @@ func (c *Cache) Add(key string, val []byte) {
 		CreatedAt: time.Now(),
 		Val:       val,
 		Size:      newSize,
+		TTL:       ttl,
 	}
 	c.currentSize += newSize
 
@@ -132,7 +140,7 @@ func (c *Cache) Get(key string) ([]byte, bool) {
 	atomic.AddUint64(&c.stats.TotalRequests, 1)
 
 	if entry, ok := c.cache[key]; ok {
-		if time.Since(entry.CreatedAt) > c.config.ExpireAfter {
+		if c.expired(entry, time.Now()) {
 			atomic.AddUint64(&c.stats.Evictions, 1)
 			delete(c.cache, key)
 			c.currentSize -= entry.Size
@@ -151,7 +159,7 @@ func (c *Cache) reapLoop(interval time.Duration) {
 		c.mu.Lock()
 		now := time.Now()
 		for key, entry := range c.cache {
-			if now.Sub(entry.CreatedAt) > c.config.ExpireAfter {
+			if c.expired(entry, now) {
 				c.currentSize -= entry.Size
 				delete(c.cache, key)
 			}
@@ -160,6 +168,14 @@ func (c *Cache) reapLoop(interval time.Duration) {
 	}
 }
 
+func (c *Cache) expired(entry cacheEntry, now time.Time) bool {
+	ttl := entry.TTL
+	if ttl == 0 {
+		ttl = c.config.ExpireAfter
+	}
+	return now.Sub(entry.CreatedAt) > ttl
+}
+
 func (c *Cache) PrintCache() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -68,6 +68,29 @@ func TestReapLoop(t *testing.T) {
 	}
 }
 
+func TestAddWithTTL(t *testing.T) {
+	const ttl = 5 * time.Millisecond
+	cache := NewCache(CacheConfig{
+		ExpireAfter: time.Hour,
+	})
+	cache.Add("https://example.com/long", []byte("testdata"))
+	cache.AddWithTTL("https://example.com/short", []byte("testdata"), ttl)
+
+	if _, ok := cache.Get("https://example.com/short"); !ok {
+		t.Errorf("expected to find key")
+		return
+	}
+
+	time.Sleep(ttl + 5*time.Millisecond)
+
+	if _, ok := cache.Get("https://example.com/short"); ok {
+		t.Errorf("expected the short lived key to expire")
+	}
+	if _, ok := cache.Get("https://example.com/long"); !ok {
+		t.Errorf("expected the other key to stay")
+	}
+}
+
 func TestMaxSize(t *testing.T) {
 	cache := NewCache(CacheConfig{
 		MaxSize: 10, // 10 bytes total
@@ -0,0 +1,183 @@
+package catalog
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type App struct {
+	AppID int    `json:"appid"`
+	Name  string `json:"name"`
+}
+
+type CatalogConfig struct {
+	ProjectName string // Name of the catalog
+	CatalogPath string // Optional custom path override
+}
+
+// Catalog is a local copy of the Steam app list with a trigram index for
+// name searches.
+type Catalog struct {
+	mu        *sync.RWMutex
+	filePath  string
+	apps      []App
+	byID      map[int]int
+	names     []string
+	grams     map[string][]int32
+	gramCount []int
+	updatedAt time.Time
+}
+
+type catalogFile struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	Apps      []App     `json:"apps"`
+}
+
+func NewCatalog(config CatalogConfig) *Catalog {
+	if config.ProjectName == "" {
+		config.ProjectName = "unnamed-project"
+	}
+
+	c := &Catalog{
+		mu:       &sync.RWMutex{},
+		filePath: getCatalogFilePath(config),
+	}
+	c.index(nil)
+
+	if err := os.MkdirAll(filepath.Dir(c.filePath), 0755); err != nil {
+		log.Printf("Warning: Catalog directory creation failed: %v. Continuing with in-memory catalog only\n", err)
+	}
+
+	if err := c.Load(); err != nil {
+		log.Printf("Error loading catalog: %v\n", err)
+	}
+
+	return c
+}
+
+// Replace swaps the catalog contents for a new app list and saves it.
+func (c *Catalog) Replace(apps []App) error {
+	c.mu.Lock()
+	c.index(apps)
+	c.updatedAt = time.Now().UTC()
+	c.mu.Unlock()
+
+	return c.Save()
+}
+
+func (c *Catalog) Lookup(appID int) (App, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	i, ok := c.byID[appID]
+	if !ok {
+		return App{}, false
+	}
+	return c.apps[i], true
+}
+
+func (c *Catalog) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.apps)
+}
+
+func (c *Catalog) UpdatedAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.updatedAt
+}
+
+func (c *Catalog) Save() error {
+	c.mu.RLock()
+	data, err := json.Marshal(catalogFile{
+		UpdatedAt: c.updatedAt,
+		Apps:      c.apps,
+	})
+	c.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("marshalling catalog: %w", err)
+	}
+
+	file, err := os.Create(c.filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gw := gzip.NewWriter(file)
+	if _, err := gw.Write(data); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func (c *Catalog) Load() error {
+	file, err := os.Open(c.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+
+	gr, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	var saved catalogFile
+	if err := json.NewDecoder(gr).Decode(&saved); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.index(saved.Apps)
+	c.updatedAt = saved.UpdatedAt
+
+	return nil
+}
+
+// index rebuilds the lookup tables. The caller must hold the write lock.
+func (c *Catalog) index(apps []App) {
+	c.apps = apps
+	c.byID = make(map[int]int, len(apps))
+	c.names = make([]string, len(apps))
+	c.grams = make(map[string][]int32)
+	c.gramCount = make([]int, len(apps))
+
+	for i, app := range apps {
+		c.byID[app.AppID] = i
+		c.names[i] = normalize(app.Name)
+
+		appGrams := trigrams(c.names[i])
+		c.gramCount[i] = len(appGrams)
+		for gram := range appGrams {
+			c.grams[gram] = append(c.grams[gram], int32(i))
+		}
+	}
+}
+
+func getCatalogFilePath(config CatalogConfig) string {
+	basePath := config.CatalogPath
+	if basePath == "" {
+		if path, err := os.UserConfigDir(); err == nil {
+			basePath = path
+		} else if path, err := os.UserHomeDir(); err == nil {
+			basePath = path
+		} else {
+			basePath = "."
+		}
+	}
+
+	return filepath.Join(basePath, config.ProjectName+"-data", "catalog.json.gz")
+}
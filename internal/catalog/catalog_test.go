@@ -0,0 +1,69 @@
+package catalog
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	c := NewCatalog(CatalogConfig{
+		CatalogPath: t.TempDir(),
+	})
+	err := c.Replace([]App{
+		{AppID: 292030, Name: "The Witcher® 3: Wild Hunt"},
+		{AppID: 20920, Name: "The Witcher 2: Assassins of Kings Enhanced Edition"},
+		{AppID: 730, Name: "Counter-Strike 2"},
+		{AppID: 440, Name: "Team Fortress 2"},
+		{AppID: 570, Name: "Dota 2"},
+		{AppID: 620, Name: "Portal 2"},
+		{AppID: 400, Name: "Portal"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		query    string
+		expected int
+	}{
+		{query: "portal", expected: 400},
+		{query: "Portal 2", expected: 620},
+		{query: "wticher 3", expected: 292030},
+		{query: "witcher wild hunt", expected: 292030},
+		{query: "team fortres", expected: 440},
+		{query: "counter strike", expected: 730},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
+			matches := c.Search(tc.query, 3)
+			if len(matches) == 0 {
+				t.Fatalf("expected matches for %q", tc.query)
+			}
+			if matches[0].AppID != tc.expected {
+				t.Errorf("expected %d for %q, got %+v", tc.expected, tc.query, matches)
+			}
+		})
+	}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	dir := t.TempDir()
+	c := NewCatalog(CatalogConfig{
+		CatalogPath: dir,
+	})
+	if err := c.Replace([]App{{AppID: 570, Name: "Dota 2"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reloaded := NewCatalog(CatalogConfig{
+		CatalogPath: dir,
+	})
+	app, ok := reloaded.Lookup(570)
+	if !ok || app.Name != "Dota 2" {
+		t.Errorf("expected to find app after reload, got %+v", app)
+	}
+	if reloaded.UpdatedAt().IsZero() {
+		t.Errorf("expected update time to be kept")
+	}
+}
@@ -0,0 +1,156 @@
+package catalog
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type Match struct {
+	App
+	Score float64 `json:"score"`
+}
+
+// Below this share of common trigrams a name isn't worth scoring
+const minGramShare = 0.3
+
+// Search returns the apps whose name best matches the query. Candidates come
+// from the trigram index, which tolerates typos, and are ranked by trigram
+// similarity and per-word edit distance, with a bonus for exact, prefix and
+// substring matches.
+func (c *Catalog) Search(query string, limit int) []Match {
+	q := normalize(query)
+	if q == "" || limit <= 0 {
+		return []Match{}
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	queryGrams := trigrams(q)
+	hits := make(map[int32]int)
+	for gram := range queryGrams {
+		for _, i := range c.grams[gram] {
+			hits[i]++
+		}
+	}
+
+	minHits := int(math.Ceil(float64(len(queryGrams)) * minGramShare))
+	queryWords := strings.Fields(q)
+
+	matches := []Match{}
+	for i, common := range hits {
+		if common < minHits {
+			continue
+		}
+
+		name := c.names[i]
+		dice := 2 * float64(common) / float64(len(queryGrams)+c.gramCount[i])
+		score := (dice + wordSimilarity(queryWords, strings.Fields(name))) / 2
+
+		switch {
+		case name == q:
+			score += 1
+		case strings.HasPrefix(name, q):
+			score += 0.5
+		case strings.Contains(name, q):
+			score += 0.25
+		}
+
+		matches = append(matches, Match{App: c.apps[i], Score: score})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		if len(matches[i].Name) != len(matches[j].Name) {
+			return len(matches[i].Name) < len(matches[j].Name)
+		}
+		return matches[i].AppID < matches[j].AppID
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// normalize lowercases the name and turns punctuation and symbols like ™ into
+// single spaces.
+func normalize(name string) string {
+	var b strings.Builder
+	space := true
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			space = false
+			continue
+		}
+		if !space {
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func trigrams(s string) map[string]struct{} {
+	runes := []rune(" " + s + " ")
+	grams := make(map[string]struct{}, len(runes))
+	for i := 0; i+3 <= len(runes); i++ {
+		grams[string(runes[i:i+3])] = struct{}{}
+	}
+	return grams
+}
+
+// wordSimilarity averages, for every query word, how close the nearest word
+// of the name is.
+func wordSimilarity(queryWords, nameWords []string) float64 {
+	if len(queryWords) == 0 || len(nameWords) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, qw := range queryWords {
+		best := 0.0
+		for _, nw := range nameWords {
+			longest := max(len([]rune(qw)), len([]rune(nw)))
+			similarity := 1 - float64(editDistance(qw, nw))/float64(longest)
+			if similarity > best {
+				best = similarity
+			}
+		}
+		total += best
+	}
+	return total / float64(len(queryWords))
+}
+
+// editDistance is the Damerau-Levenshtein distance, so swapped letters count
+// as a single typo.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
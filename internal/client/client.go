@@ -1,22 +1,45 @@
 package client
 
 import (
+	"log"
 	"net/http"
+	"net/http/cookiejar"
 	"time"
 
 	"github.com/masintxi/gamehub/internal/cache"
+	"github.com/masintxi/gamehub/internal/catalog"
+	"github.com/masintxi/gamehub/internal/store"
 )
 
 type Client struct {
 	HttpClient *http.Client
+	Jar        http.CookieJar
+	Metrics    *Metrics
 	Cache      *cache.Cache
+	Store      *store.Store
+	Catalog    *catalog.Catalog
 }
 
-func NewClient(cacheConfig cache.CacheConfig) *Client {
+func NewClient(cacheConfig cache.CacheConfig, storeConfig store.StoreConfig, catalogConfig catalog.CatalogConfig, transportConfig TransportConfig) *Client {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		log.Fatalf("Failed to create cookie jar: %v", err)
+	}
+	metrics := NewMetrics()
+	transport, err := newTransport(transportConfig, jar, metrics)
+	if err != nil {
+		log.Fatalf("Failed to create transport: %v", err)
+	}
+
 	return &Client{
 		HttpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Transport: transport,
+			Timeout:   30 * time.Second,
 		},
-		Cache: cache.NewCache(cacheConfig),
+		Jar:     jar,
+		Metrics: metrics,
+		Cache:   cache.NewCache(cacheConfig),
+		Store:   store.NewStore(storeConfig),
+		Catalog: catalog.NewCatalog(catalogConfig),
 	}
 }
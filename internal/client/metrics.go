@@ -0,0 +1,68 @@
+package client
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Metrics keeps counters of the upstream requests, by host.
+type Metrics struct {
+	mu    sync.Mutex
+	hosts map[string]*HostMetrics
+}
+
+type HostMetrics struct {
+	Requests     uint64            `json:"requests"`
+	Errors       uint64            `json:"errors"`
+	RateLimited  uint64            `json:"rate_limited"`
+	StatusCodes  map[string]uint64 `json:"status_codes"`
+	TotalLatency time.Duration     `json:"-"`
+	AvgLatencyMs float64           `json:"avg_latency_ms"`
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{hosts: make(map[string]*HostMetrics)}
+}
+
+func (m *Metrics) record(host string, status int, err error, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hm, ok := m.hosts[host]
+	if !ok {
+		hm = &HostMetrics{StatusCodes: make(map[string]uint64)}
+		m.hosts[host] = hm
+	}
+
+	hm.Requests++
+	hm.TotalLatency += latency
+	if err != nil {
+		hm.Errors++
+		return
+	}
+	hm.StatusCodes[strconv.Itoa(status)]++
+	if status == 429 {
+		hm.RateLimited++
+	}
+}
+
+// Snapshot returns a copy of the counters of every host.
+func (m *Metrics) Snapshot() map[string]HostMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]HostMetrics, len(m.hosts))
+	for host, hm := range m.hosts {
+		copied := *hm
+		copied.StatusCodes = make(map[string]uint64, len(hm.StatusCodes))
+		for code, count := range hm.StatusCodes {
+			copied.StatusCodes[code] = count
+		}
+		if hm.Requests > 0 {
+			copied.AvgLatencyMs = float64(hm.TotalLatency.Milliseconds()) / float64(hm.Requests)
+		}
+		snapshot[host] = copied
+	}
+	return snapshot
+}
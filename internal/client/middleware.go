@@ -0,0 +1,215 @@
+package client
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// UserAgent sets the User-Agent of requests that don't have one.
+func UserAgent(userAgent string) Middleware {
+	return DefaultHeaders(map[string]string{"User-Agent": userAgent})
+}
+
+// DefaultHeaders adds the headers the request doesn't already set.
+func DefaultHeaders(headers map[string]string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		if len(headers) == 0 {
+			return next
+		}
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for key, value := range headers {
+				if req.Header.Get(key) == "" {
+					req.Header.Set(key, value)
+				}
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// Cookies sends the cookies the jar holds for the request URL and keeps the
+// ones set by the response.
+func Cookies(jar http.CookieJar) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		if jar == nil {
+			return next
+		}
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for _, cookie := range jar.Cookies(req.URL) {
+				if _, err := req.Cookie(cookie.Name); err == http.ErrNoCookie {
+					req.AddCookie(cookie)
+				}
+			}
+
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+			if cookies := resp.Cookies(); len(cookies) > 0 {
+				jar.SetCookies(req.URL, cookies)
+			}
+			return resp, nil
+		})
+	}
+}
+
+// Logging logs every request with its status and duration. The API key is
+// removed from the logged URL.
+func Logging() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				log.Printf("%s %s failed after %v: %v", req.Method, RedactURL(req.URL), time.Since(start), err)
+				return nil, err
+			}
+			log.Printf("%s %s %d in %v", req.Method, RedactURL(req.URL), resp.StatusCode, time.Since(start))
+			return resp, nil
+		})
+	}
+}
+
+// Retry retries idempotent requests that failed with a network error or a
+// 5xx status. The wait starts at backoff and doubles on each attempt; a
+// Retry-After header from Steam takes precedence.
+func Retry(maxRetries int, backoff time.Duration) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		if maxRetries <= 0 {
+			return next
+		}
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodGet && req.Method != http.MethodHead {
+				return next.RoundTrip(req)
+			}
+
+			wait := backoff
+			for attempt := 0; ; attempt++ {
+				resp, err := next.RoundTrip(req)
+				if attempt == maxRetries || !shouldRetry(resp, err) {
+					return resp, err
+				}
+
+				delay := wait
+				if resp != nil {
+					if after := retryAfter(resp); after > 0 {
+						delay = after
+					}
+					resp.Body.Close()
+				}
+				wait *= 2
+
+				select {
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				case <-time.After(delay):
+				}
+			}
+		})
+	}
+}
+
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// RateLimit spaces the requests going through it by at least interval.
+// Waiting requests give up when their context is cancelled.
+func RateLimit(interval time.Duration) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		var mu sync.Mutex
+		var nextSlot time.Time
+
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			now := time.Now()
+			if nextSlot.Before(now) {
+				nextSlot = now
+			}
+			wait := nextSlot.Sub(now)
+			nextSlot = nextSlot.Add(interval)
+			mu.Unlock()
+
+			if wait > 0 {
+				timer := time.NewTimer(wait)
+				defer timer.Stop()
+				select {
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				case <-timer.C:
+				}
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// CollectMetrics counts the requests, errors and latency of each host.
+func CollectMetrics(metrics *Metrics) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		if metrics == nil {
+			return next
+		}
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			status := 0
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			metrics.record(req.URL.Hostname(), status, err, time.Since(start))
+			return resp, err
+		})
+	}
+}
+
+// RedactURL returns the URL without the Steam API key, for logs and
+// fixtures.
+func RedactURL(u *url.URL) string {
+	q := u.Query()
+	if !q.Has("key") {
+		return u.String()
+	}
+	q.Set("key", "REDACTED")
+	redacted := *u
+	redacted.RawQuery = q.Encode()
+	return redacted.String()
+}
+
+// Upstream sends every request to the target server instead of the host of
+// its URL. The Host header keeps the original host, so the target can tell
+// which service the request was meant for.
+func Upstream(target *url.URL) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			if req.Host == "" {
+				req.Host = req.URL.Host
+			}
+			req.URL.Scheme = target.Scheme
+			req.URL.Host = target.Host
+			return next.RoundTrip(req)
+		})
+	}
+}
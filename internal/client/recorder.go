@@ -0,0 +1,265 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	// Where the client sends its requests
+	ModeLive   = ""       // Straight to Steam
+	ModeRecord = "record" // To Steam, saving every exchange as a fixture
+	ModeReplay = "replay" // Never to Steam, answered from the fixtures
+
+	// Replay matching
+	MatchStrict  = "strict"  // Method and full URL must match
+	MatchLenient = "lenient" // Method, host and path must match, closest query wins
+)
+
+// Fixture is one recorded upstream exchange. The API key, the cookies and the
+// session and wallet data of the body are scrubbed before it is written, so
+// fixtures can be committed.
+type Fixture struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+}
+
+// Scrubbed response headers, they carry session data
+var scrubbedHeaders = []string{"Set-Cookie", "Cookie"}
+
+// Cookies whose values are scrubbed from the bodies too, community pages
+// embed them
+var sessionCookies = []string{"sessionid", "steamLoginSecure", "steamRefresh_steam"}
+
+// Session and wallet data of the bodies. The session ID and the tokens are
+// replaced, the balances zeroed so the wallet info keeps its shape.
+var scrubbedBodyPatterns = []struct {
+	re          *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`(g_sessionID\s*=\s*")[^"]*(")`), "${1}REDACTED${2}"},
+	{regexp.MustCompile(`("(?:webapi_token|access_token|loyalty_webapi_token)"\s*:\s*")[^"]*(")`), "${1}REDACTED${2}"},
+	{regexp.MustCompile(`("wallet_(?:balance|delayed_balance|max_balance|trade_max_balance)"\s*:\s*"?)\d+`), "${1}0"},
+}
+
+// Record saves every exchange that goes through it into dir.
+func Record(dir string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		var mu sync.Mutex
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, fmt.Errorf("recording response: %w", err)
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+
+			header := resp.Header.Clone()
+			for _, name := range scrubbedHeaders {
+				header.Del(name)
+			}
+			fixture := Fixture{
+				Method: req.Method,
+				URL:    fixtureURL(req.URL),
+				Status: resp.StatusCode,
+				Header: header,
+				Body:   scrubBody(string(body), req, resp),
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err := writeFixture(dir, fixture); err != nil {
+				return nil, fmt.Errorf("recording response: %w", err)
+			}
+			return resp, nil
+		})
+	}
+}
+
+// scrubBody removes the session ID, the tokens and the wallet balances from
+// a recorded body, along with the value of every session cookie sent or set
+// in the exchange.
+func scrubBody(body string, req *http.Request, resp *http.Response) string {
+	for _, pattern := range scrubbedBodyPatterns {
+		body = pattern.re.ReplaceAllString(body, pattern.replacement)
+	}
+
+	cookies := append(req.Cookies(), resp.Cookies()...)
+	for _, cookie := range cookies {
+		if !slices.Contains(sessionCookies, cookie.Name) || cookie.Value == "" {
+			continue
+		}
+		body = strings.ReplaceAll(body, cookie.Value, "REDACTED")
+		if decoded, err := url.QueryUnescape(cookie.Value); err == nil && decoded != cookie.Value {
+			body = strings.ReplaceAll(body, decoded, "REDACTED")
+		}
+	}
+
+	return body
+}
+
+// Replayer answers requests from the fixtures of a directory, without any
+// network access.
+type Replayer struct {
+	fixtures map[string][]Fixture // By method, host and path
+	match    string
+}
+
+// NewReplayer loads every fixture of dir.
+func NewReplayer(dir, match string) (*Replayer, error) {
+	if match == "" {
+		match = MatchStrict
+	}
+	if match != MatchStrict && match != MatchLenient {
+		return nil, fmt.Errorf("unknown fixture matching %q", match)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("listing fixtures: %w", err)
+	}
+
+	r := &Replayer{
+		fixtures: make(map[string][]Fixture),
+		match:    match,
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading fixture: %w", err)
+		}
+
+		var fixture Fixture
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf("decoding fixture %s: %w", filepath.Base(path), err)
+		}
+		u, err := url.Parse(fixture.URL)
+		if err != nil {
+			return nil, fmt.Errorf("fixture %s: %w", filepath.Base(path), err)
+		}
+		fixture.URL = fixtureURL(u)
+
+		key := endpointKey(fixture.Method, u)
+		r.fixtures[key] = append(r.fixtures[key], fixture)
+	}
+
+	return r, nil
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	fixture, ok := r.find(req)
+	if !ok {
+		return nil, fmt.Errorf("no fixture for %s %s", req.Method, fixtureURL(req.URL))
+	}
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	header := fixture.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fixture.Status, http.StatusText(fixture.Status)),
+		StatusCode:    fixture.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(fixture.Body)),
+		ContentLength: int64(len(fixture.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Replayer) find(req *http.Request) (Fixture, bool) {
+	candidates := r.fixtures[endpointKey(req.Method, req.URL)]
+	want := fixtureURL(req.URL)
+
+	for _, fixture := range candidates {
+		if fixture.URL == want {
+			return fixture, true
+		}
+	}
+	if r.match == MatchStrict || len(candidates) == 0 {
+		return Fixture{}, false
+	}
+
+	// Lenient: the fixture sharing the most query parameters, the first one
+	// recorded on ties
+	query := req.URL.Query()
+	best, bestScore := 0, -1
+	for i, fixture := range candidates {
+		u, _ := url.Parse(fixture.URL)
+		score := 0
+		for key, values := range u.Query() {
+			if query.Get(key) == values[0] {
+				score++
+			}
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return candidates[best], true
+}
+
+// fixtureURL is the scrubbed URL with sorted query parameters, so the same
+// request always gives the same fixture.
+func fixtureURL(u *url.URL) string {
+	scrubbed := *u
+	q := u.Query()
+	if q.Has("key") {
+		q.Set("key", "REDACTED")
+	}
+	scrubbed.RawQuery = q.Encode()
+	scrubbed.Fragment = ""
+	return scrubbed.String()
+}
+
+func endpointKey(method string, u *url.URL) string {
+	return method + " " + strings.ToLower(u.Host) + u.Path
+}
+
+func writeFixture(dir string, fixture Fixture) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	sum := sha1.Sum([]byte(fixture.Method + " " + fixture.URL))
+	u, _ := url.Parse(fixture.URL)
+	name := fmt.Sprintf("%s-%s.json", strings.ReplaceAll(u.Hostname(), ".", "_"), hex.EncodeToString(sum[:6]))
+
+	// Write to a temporary file first, so a crash never leaves half a fixture
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
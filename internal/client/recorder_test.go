@@ -0,0 +1,122 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "steamLoginSecure", Value: "secret-cookie"})
+		w.Write([]byte(`{"steamid":"` + r.URL.Query().Get("steamid") + `"}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recording := &http.Client{Transport: Chain(http.DefaultTransport, Record(dir))}
+	for _, steamID := range []string{"1", "2"} {
+		resp, err := recording.Get(server.URL + "/GetPlayerSummaries/?steamid=" + steamID + "&key=secret-key")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	// Fixtures never hold the API key nor the cookies
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(paths) != 2 {
+		t.Fatalf("expected 2 fixtures, got %d", len(paths))
+	}
+	for _, path := range paths {
+		data, _ := os.ReadFile(path)
+		if strings.Contains(string(data), "secret-key") || strings.Contains(string(data), "secret-cookie") {
+			t.Errorf("fixture %s was not scrubbed: %s", filepath.Base(path), data)
+		}
+	}
+
+	cases := []struct {
+		match  string
+		query  string
+		want   string
+		wantOK bool
+	}{
+		{match: MatchStrict, query: "?steamid=2&key=other-key", want: `{"steamid":"2"}`, wantOK: true},
+		{match: MatchStrict, query: "?key=other-key&steamid=1", want: `{"steamid":"1"}`, wantOK: true},
+		{match: MatchStrict, query: "?steamid=3&key=other-key", wantOK: false},
+		{match: MatchLenient, query: "?steamid=2&key=other-key&format=json", want: `{"steamid":"2"}`, wantOK: true},
+		{match: MatchLenient, query: "?steamid=3", wantOK: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.match+c.query, func(t *testing.T) {
+			replayer, err := NewReplayer(dir, c.match)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			resp, err := (&http.Client{Transport: replayer}).Get(server.URL + "/GetPlayerSummaries/" + c.query)
+			if !c.wantOK {
+				if err == nil {
+					t.Errorf("expected no fixture to match")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			body, _ := io.ReadAll(resp.Body)
+			if c.want != "" && string(body) != c.want {
+				t.Errorf("expected %s, got %s", c.want, body)
+			}
+		})
+	}
+}
+
+func TestRecordScrubsBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "sessionid", Value: "d3c0ffee5e551011"})
+		w.Write([]byte(`<script>
+	var g_sessionID = "d3c0ffee5e551011";
+	var g_rgWalletInfo = {"wallet_currency":3,"wallet_country":"ES","wallet_balance":"12345","wallet_delayed_balance":0,"wallet_max_balance":200000};
+	var g_strLogin = "76561197960287930||eyAidHlwIjogIkpXVCIgfQ";
+	var config = {"webapi_token":"a1b2c3d4"};
+</script>`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recording := &http.Client{Transport: Chain(http.DefaultTransport, Record(dir))}
+	req, _ := http.NewRequest("GET", server.URL+"/market/listings/730/AK-47", nil)
+	req.AddCookie(&http.Cookie{Name: "steamLoginSecure", Value: "76561197960287930%7C%7CeyAidHlwIjogIkpXVCIgfQ"})
+	resp, err := recording.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(paths) != 1 {
+		t.Fatalf("expected 1 fixture, got %d", len(paths))
+	}
+	data, _ := os.ReadFile(paths[0])
+	body := string(data)
+
+	for _, secret := range []string{"d3c0ffee5e551011", "12345", "200000", "eyAidHlwIjogIkpXVCIgfQ", "a1b2c3d4"} {
+		if strings.Contains(body, secret) {
+			t.Errorf("expected %s to be scrubbed: %s", secret, body)
+		}
+	}
+	// Parsing still needs the wallet currency
+	for _, kept := range []string{`g_sessionID = \"REDACTED\"`, `\"wallet_currency\":3`, `\"wallet_balance\":\"0\"`} {
+		if !strings.Contains(body, kept) {
+			t.Errorf("expected %s in the fixture: %s", kept, body)
+		}
+	}
+}
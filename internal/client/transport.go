@@ -0,0 +1,133 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Middleware wraps a RoundTripper with one concern of the outbound requests,
+// such as headers, retries or rate limits.
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc turns a function into an http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Chain wraps base with the middlewares. The first middleware is the
+// outermost one, so it sees the request first and the response last.
+func Chain(base http.RoundTripper, middlewares ...Middleware) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		base = middlewares[i](base)
+	}
+	return base
+}
+
+type TransportConfig struct {
+	UserAgent    string                // User-Agent of every request
+	Headers      map[string]string     // Headers added to every request
+	MaxRetries   int                   // Retries of failed idempotent requests
+	RetryBackoff time.Duration         // Wait before the first retry, doubled on each one
+	LogRequests  bool                  // Whether to log every upstream request
+	Hosts        map[string]HostConfig // Settings of each upstream host
+	Mode         string                // ModeLive, ModeRecord or ModeReplay
+	FixturesDir  string                // Where fixtures are recorded and replayed from
+	FixtureMatch string                // MatchStrict or MatchLenient when replaying
+	UpstreamURL  string                // Server standing in for every Steam host, such as the fake one of demo mode
+}
+
+type HostConfig struct {
+	Headers     map[string]string // Headers added on top of the default ones
+	MinInterval time.Duration     // Minimum time between two requests to the host
+	Cookies     bool              // Whether to send the cookies of the client jar
+}
+
+// hostTransport sends every request through the chain of its host, or the
+// default chain for hosts without settings.
+type hostTransport struct {
+	hosts    map[string]http.RoundTripper
+	fallback http.RoundTripper
+}
+
+func (t *hostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt, ok := t.hosts[strings.ToLower(req.URL.Hostname())]; ok {
+		return rt.RoundTrip(req)
+	}
+	return t.fallback.RoundTrip(req)
+}
+
+func newTransport(config TransportConfig, jar http.CookieJar, metrics *Metrics) (http.RoundTripper, error) {
+	if config.UserAgent == "" {
+		config.UserAgent = "Mozilla/5.0"
+	}
+	if config.RetryBackoff == 0 {
+		config.RetryBackoff = 500 * time.Millisecond
+	}
+
+	// One base transport keeps a single connection pool for every host
+	var base http.RoundTripper = http.DefaultTransport.(*http.Transport).Clone()
+	switch config.Mode {
+	case ModeLive, ModeRecord:
+	case ModeReplay:
+		replayer, err := NewReplayer(config.FixturesDir, config.FixtureMatch)
+		if err != nil {
+			return nil, err
+		}
+		base = replayer
+	default:
+		return nil, fmt.Errorf("unknown transport mode %q", config.Mode)
+	}
+
+	var upstream *url.URL
+	if config.UpstreamURL != "" {
+		u, err := url.Parse(config.UpstreamURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid upstream URL: %w", err)
+		}
+		upstream = u
+	}
+
+	chain := func(host HostConfig) http.RoundTripper {
+		middlewares := []Middleware{}
+		if config.LogRequests {
+			middlewares = append(middlewares, Logging())
+		}
+		middlewares = append(middlewares, Retry(config.MaxRetries, config.RetryBackoff))
+		if host.MinInterval > 0 && config.Mode != ModeReplay && upstream == nil {
+			middlewares = append(middlewares, RateLimit(host.MinInterval))
+		}
+		middlewares = append(middlewares,
+			CollectMetrics(metrics),
+			UserAgent(config.UserAgent),
+			DefaultHeaders(config.Headers),
+			DefaultHeaders(host.Headers),
+		)
+		if host.Cookies {
+			middlewares = append(middlewares, Cookies(jar))
+		}
+		if config.Mode == ModeRecord {
+			middlewares = append(middlewares, Record(config.FixturesDir))
+		}
+		if upstream != nil {
+			middlewares = append(middlewares, Upstream(upstream))
+		}
+		return Chain(base, middlewares...)
+	}
+
+	t := &hostTransport{
+		hosts:    make(map[string]http.RoundTripper),
+		fallback: chain(HostConfig{}),
+	}
+	for name, host := range config.Hosts {
+		t.hosts[strings.ToLower(name)] = chain(host)
+	}
+	return t, nil
+}
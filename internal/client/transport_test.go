@@ -0,0 +1,137 @@
+package client
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestChainOrder(t *testing.T) {
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	base := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		order = append(order, "base")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+
+	req := httptest.NewRequest("GET", "https://example.com", nil)
+	if _, err := Chain(base, mark("first"), mark("second")).RoundTrip(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"first", "second", "base"}
+	if len(order) != len(want) {
+		t.Fatalf("expected %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, order)
+		}
+	}
+}
+
+func TestHeadersAndCookies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ua := r.Header.Get("User-Agent"); ua != "gamehub-test" {
+			t.Errorf("expected user agent gamehub-test, got %q", ua)
+		}
+		if accept := r.Header.Get("Accept"); accept != "application/json" {
+			t.Errorf("expected accept application/json, got %q", accept)
+		}
+		if cookie, err := r.Cookie("sessionid"); err != nil || cookie.Value != "abc" {
+			t.Errorf("expected sessionid cookie, got %v", cookie)
+		}
+		http.SetCookie(w, &http.Cookie{Name: "steamCountry", Value: "ES"})
+	}))
+	defer server.Close()
+
+	jar, _ := cookiejar.New(nil)
+	serverURL, _ := url.Parse(server.URL)
+	jar.SetCookies(serverURL, []*http.Cookie{{Name: "sessionid", Value: "abc"}})
+
+	rt := Chain(http.DefaultTransport,
+		UserAgent("gamehub-test"),
+		DefaultHeaders(map[string]string{"Accept": "application/json"}),
+		Cookies(jar),
+	)
+	resp, err := (&http.Client{Transport: rt}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	found := false
+	for _, cookie := range jar.Cookies(serverURL) {
+		if cookie.Name == "steamCountry" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the response cookie to be saved in the jar")
+	}
+}
+
+func TestRetry(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	rt := Chain(http.DefaultTransport, Retry(2, time.Millisecond))
+	resp, err := (&http.Client{Transport: rt}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	const interval = 20 * time.Millisecond
+	base := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+	rt := Chain(base, RateLimit(interval))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest("GET", "https://example.com", nil)
+		if _, err := rt.RoundTrip(req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 2*interval {
+		t.Errorf("expected at least %v between three requests, got %v", 2*interval, elapsed)
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	u, _ := url.Parse("https://api.steampowered.com/ISteamUser/GetPlayerSummaries/v0002/?key=SECRET&steamids=1")
+	redacted := RedactURL(u)
+	if redacted != "https://api.steampowered.com/ISteamUser/GetPlayerSummaries/v0002/?key=REDACTED&steamids=1" {
+		t.Errorf("unexpected redacted URL %q", redacted)
+	}
+}
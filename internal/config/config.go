@@ -4,12 +4,17 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/masintxi/gamehub/internal/auth"
 	"github.com/masintxi/gamehub/internal/cache"
+	"github.com/masintxi/gamehub/internal/catalog"
+	"github.com/masintxi/gamehub/internal/client"
 	"github.com/masintxi/gamehub/internal/server"
+	"github.com/masintxi/gamehub/internal/store"
 )
 
 type Config struct {
@@ -21,9 +26,20 @@ type Config struct {
 
 	// Cache Configuration
 	CacheConfig cache.CacheConfig
+
+	// Store Configuration
+	StoreConfig store.StoreConfig
+
+	// App Catalog Configuration
+	CatalogConfig catalog.CatalogConfig
+
+	// Outbound HTTP Configuration
+	TransportConfig client.TransportConfig
 }
 
-func Load() *Config {
+// Load reads the configuration from the environment. In demo mode Steam is
+// the fake one, so no API key is needed and the data is kept apart.
+func Load(demo bool) *Config {
 	if err := godotenv.Load(); err != nil {
 		log.Printf("Warning: .env file not found")
 	}
@@ -39,9 +55,24 @@ func Load() *Config {
 	if cfg.Server.Domain == "" {
 		cfg.Server.Domain = "localhost"
 	}
+	cfg.Server.Debug = os.Getenv("DEBUG_ENDPOINTS") == "true"
+
+	// Set outbound HTTP mode, replaying fixtures needs no network nor API key
+	cfg.TransportConfig.Mode = os.Getenv("STEAM_TRANSPORT_MODE")
+	cfg.TransportConfig.FixturesDir = os.Getenv("FIXTURES_DIR")
+	if cfg.TransportConfig.FixturesDir == "" {
+		cfg.TransportConfig.FixturesDir = "fixtures"
+	}
+	cfg.TransportConfig.FixtureMatch = os.Getenv("FIXTURE_MATCH")
 
 	// Load Steam config
 	cfg.SteamAuth.ApiKey = os.Getenv("STEAM_API_KEY")
+	if cfg.SteamAuth.ApiKey == "" && cfg.TransportConfig.Mode == client.ModeReplay {
+		cfg.SteamAuth.ApiKey = "REDACTED"
+	}
+	if demo {
+		cfg.SteamAuth.ApiKey = "demo"
+	}
 	if cfg.SteamAuth.ApiKey == "" {
 		log.Fatal("STEAM_API_KEY not set")
 	}
@@ -53,13 +84,98 @@ func Load() *Config {
 	cfg.SteamAuth.CallbackURL = fmt.Sprintf("http://%s:%s/auth/steam/callback",
 		cfg.Server.Domain, cfg.Server.Port)
 
+	projectName := "gamehub"
+	if demo {
+		projectName = "gamehub-demo"
+	}
+
 	// Set cache config
-	cfg.CacheConfig.ProjectName = "gamehub"
+	cfg.CacheConfig.ProjectName = projectName
 	cfg.CacheConfig.CleanupInterval = 5 * time.Second
 	cfg.CacheConfig.MaxSize = 1024 * 1024 * 10 // 10 MB
 	cfg.CacheConfig.FileExtension = "json"
 	cfg.CacheConfig.Compression = true
 	cfg.CacheConfig.ExpireAfter = 30 * time.Minute
 
+	// Set store config
+	cfg.StoreConfig.ProjectName = projectName
+	if !demo {
+		cfg.StoreConfig.StorePath = os.Getenv("STORE_PATH")
+	}
+
+	// Set catalog config
+	cfg.CatalogConfig.ProjectName = projectName
+	if !demo {
+		cfg.CatalogConfig.CatalogPath = os.Getenv("STORE_PATH")
+	}
+
+	// Set outbound HTTP config
+	cfg.TransportConfig.UserAgent = os.Getenv("UPSTREAM_USER_AGENT")
+	cfg.TransportConfig.Headers = map[string]string{
+		"Accept": "application/json, text/javascript, */*; q=0.01",
+	}
+	cfg.TransportConfig.MaxRetries = intEnv("UPSTREAM_MAX_RETRIES", 2)
+	cfg.TransportConfig.RetryBackoff = durationEnv("UPSTREAM_RETRY_BACKOFF", 500*time.Millisecond)
+	cfg.TransportConfig.LogRequests = os.Getenv("LOG_UPSTREAM") == "true"
+	cfg.TransportConfig.Hosts = map[string]client.HostConfig{
+		"api.steampowered.com": {
+			MinInterval: durationEnv("API_REQUEST_INTERVAL", 0),
+		},
+		"store.steampowered.com": {
+			MinInterval: durationEnv("STORE_REQUEST_INTERVAL", 300*time.Millisecond),
+		},
+		"steamcommunity.com": {
+			Headers: map[string]string{
+				"Accept": "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+			},
+			MinInterval: durationEnv("COMMUNITY_REQUEST_INTERVAL", time.Second),
+			Cookies:     true,
+		},
+	}
+
+	// Set background jobs config
+	cfg.Server.Config.Jobs.PlayerCountInterval = durationEnv("PLAYER_COUNT_INTERVAL", 30*time.Minute)
+	cfg.Server.Config.Jobs.CatalogRefreshInterval = durationEnv("CATALOG_REFRESH_INTERVAL", 24*time.Hour)
+	cfg.Server.Config.Jobs.WishlistCheckInterval = durationEnv("WISHLIST_CHECK_INTERVAL", 6*time.Hour)
+	cfg.Server.Config.Jobs.PortfolioInterval = durationEnv("PORTFOLIO_SNAPSHOT_INTERVAL", 6*time.Hour)
+
+	// Set regional prices config
+	cfg.Server.Config.RegionCountries = strings.Split(os.Getenv("REGION_COUNTRIES"), ",")
+	if os.Getenv("REGION_COUNTRIES") == "" {
+		cfg.Server.Config.RegionCountries = []string{"us", "gb", "de", "pl", "tr", "ar", "br", "in", "jp", "au", "ca", "kz"}
+	}
+	cfg.Server.Config.ExchangeRatesFile = os.Getenv("EXCHANGE_RATES_FILE")
+	if cfg.Server.Config.ExchangeRatesFile == "" {
+		cfg.Server.Config.ExchangeRatesFile = "exchange_rates.json"
+	}
+
 	return cfg
 }
+
+func durationEnv(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Warning: invalid %s %q, using %v", key, value, fallback)
+		return fallback
+	}
+	return d
+}
+
+func intEnv(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Warning: invalid %s %q, using %d", key, value, fallback)
+		return fallback
+	}
+	return n
+}
@@ -0,0 +1,65 @@
+package currency
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// Rates is an exchange-rate table: how many units of each currency one unit
+// of the base currency buys.
+type Rates struct {
+	Base    string             `json:"base"`
+	Updated string             `json:"updated,omitempty"`
+	Rates   map[string]float64 `json:"rates"`
+}
+
+func LoadRates(path string) (*Rates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading exchange rates: %w", err)
+	}
+
+	var rates Rates
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, fmt.Errorf("decoding exchange rates: %w", err)
+	}
+	if rates.Base == "" {
+		return nil, fmt.Errorf("exchange rates without base currency")
+	}
+
+	normalized := make(map[string]float64, len(rates.Rates)+1)
+	for code, rate := range rates.Rates {
+		if rate <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate for %s: %v", code, rate)
+		}
+		normalized[strings.ToUpper(code)] = rate
+	}
+	rates.Base = strings.ToUpper(rates.Base)
+	normalized[rates.Base] = 1
+	rates.Rates = normalized
+
+	return &rates, nil
+}
+
+func (r *Rates) Has(code string) bool {
+	_, ok := r.Rates[strings.ToUpper(code)]
+	return ok
+}
+
+// Convert changes an amount between currencies. Amounts are kept in
+// hundredths, the way the Steam store sends every price.
+func (r *Rates) Convert(amount int, from, to string) (int, error) {
+	fromRate, ok := r.Rates[strings.ToUpper(from)]
+	if !ok {
+		return 0, fmt.Errorf("no exchange rate for %s", from)
+	}
+	toRate, ok := r.Rates[strings.ToUpper(to)]
+	if !ok {
+		return 0, fmt.Errorf("no exchange rate for %s", to)
+	}
+
+	return int(math.Round(float64(amount) / fromRate * toRate)), nil
+}
@@ -0,0 +1,73 @@
+package currency
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeRates(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "exchange_rates.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestConvert(t *testing.T) {
+	rates, err := LoadRates(writeRates(t, `{"base": "usd", "rates": {"eur": 0.9, "JPY": 150, "GBP": 0.79}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		amount   int
+		from, to string
+		want     int
+	}{
+		{1999, "USD", "USD", 1999},
+		{1999, "EUR", "eur", 1999},
+		{1000, "USD", "EUR", 900},
+		{1000, "EUR", "USD", 1111},  // 1111.1 rounds down
+		{1999, "USD", "EUR", 1799},  // 1799.1
+		{1995, "USD", "EUR", 1796},  // 1795.5 rounds up
+		{100000, "JPY", "GBP", 527}, // Through the base, 526.67
+		{500, "GBP", "JPY", 94937},  // 94936.7
+	}
+	for _, c := range cases {
+		got, err := rates.Convert(c.amount, c.from, c.to)
+		if err != nil || got != c.want {
+			t.Errorf("Convert(%d, %s, %s) = %d, %v, want %d", c.amount, c.from, c.to, got, err, c.want)
+		}
+	}
+
+	if _, err := rates.Convert(100, "USD", "XXX"); err == nil {
+		t.Errorf("expected an error for an unknown target currency")
+	}
+	if _, err := rates.Convert(100, "XXX", "USD"); err == nil {
+		t.Errorf("expected an error for an unknown source currency")
+	}
+	if !rates.Has("usd") || rates.Has("XXX") {
+		t.Errorf("expected the base to be known and XXX unknown")
+	}
+}
+
+func TestLoadRatesErrors(t *testing.T) {
+	cases := map[string]string{
+		"no base":       `{"rates": {"EUR": 0.9}}`,
+		"zero rate":     `{"base": "USD", "rates": {"EUR": 0}}`,
+		"negative rate": `{"base": "USD", "rates": {"EUR": -1}}`,
+		"not json":      `rates`,
+	}
+	for name, content := range cases {
+		if _, err := LoadRates(writeRates(t, content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if _, err := LoadRates(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,91 @@
+package currency
+
+import "strings"
+
+// Steam wallet currency ids, used by the market endpoints
+var steamCurrencyIDs = map[string]int{
+	"USD": 1,
+	"GBP": 2,
+	"EUR": 3,
+	"CHF": 4,
+	"RUB": 5,
+	"PLN": 6,
+	"BRL": 7,
+	"JPY": 8,
+	"NOK": 9,
+	"IDR": 10,
+	"MYR": 11,
+	"PHP": 12,
+	"SGD": 13,
+	"THB": 14,
+	"VND": 15,
+	"KRW": 16,
+	"TRY": 17,
+	"UAH": 18,
+	"MXN": 19,
+	"CAD": 20,
+	"AUD": 21,
+	"NZD": 22,
+	"CNY": 23,
+	"INR": 24,
+	"CLP": 25,
+	"PEN": 26,
+	"COP": 27,
+	"ZAR": 28,
+	"HKD": 29,
+	"TWD": 30,
+	"SAR": 31,
+	"AED": 32,
+	"ARS": 34,
+	"ILS": 35,
+	"KZT": 37,
+	"KWD": 38,
+	"QAR": 39,
+	"CRC": 40,
+	"UYU": 41,
+}
+
+// Currency of the Steam store of each country, when it isn't USD
+var countryCurrencies = map[string]string{
+	"gb": "GBP", "ch": "CHF", "ru": "RUB", "pl": "PLN", "br": "BRL",
+	"jp": "JPY", "no": "NOK", "id": "IDR", "my": "MYR", "ph": "PHP",
+	"sg": "SGD", "th": "THB", "vn": "VND", "kr": "KRW", "tr": "TRY",
+	"ua": "UAH", "mx": "MXN", "ca": "CAD", "au": "AUD", "nz": "NZD",
+	"cn": "CNY", "in": "INR", "cl": "CLP", "pe": "PEN", "co": "COP",
+	"za": "ZAR", "hk": "HKD", "tw": "TWD", "sa": "SAR", "ae": "AED",
+	"il": "ILS", "kz": "KZT", "kw": "KWD", "qa": "QAR", "cr": "CRC",
+	"uy": "UYU",
+	"at": "EUR", "be": "EUR", "cy": "EUR", "de": "EUR", "ee": "EUR",
+	"es": "EUR", "fi": "EUR", "fr": "EUR", "gr": "EUR", "hr": "EUR",
+	"ie": "EUR", "it": "EUR", "lt": "EUR", "lu": "EUR", "lv": "EUR",
+	"mt": "EUR", "nl": "EUR", "pt": "EUR", "si": "EUR", "sk": "EUR",
+}
+
+// SteamID returns the Steam wallet currency id of an ISO 4217 code.
+func SteamID(code string) (int, bool) {
+	id, ok := steamCurrencyIDs[strings.ToUpper(code)]
+	return id, ok
+}
+
+// Code returns the ISO 4217 code of a Steam wallet currency id.
+func Code(steamID int) (string, bool) {
+	for code, id := range steamCurrencyIDs {
+		if id == steamID {
+			return code, true
+		}
+	}
+	return "", false
+}
+
+func IsSteamCurrency(code string) bool {
+	_, ok := steamCurrencyIDs[strings.ToUpper(code)]
+	return ok
+}
+
+// ForCountry returns the currency the Steam store uses in a country.
+func ForCountry(cc string) string {
+	if code, ok := countryCurrencies[strings.ToLower(cc)]; ok {
+		return code
+	}
+	return "USD"
+}
@@ -0,0 +1,411 @@
+package fakesteam
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+func (s *Server) handlePlayerSummaries(w http.ResponseWriter, r *http.Request) {
+	players := []map[string]interface{}{}
+	for _, id := range steamIDs(r) {
+		user, ok := s.seed.user(id)
+		if !ok {
+			continue
+		}
+
+		visibility := 3
+		if user.Private {
+			visibility = 1
+		}
+		player := map[string]interface{}{
+			"steamid":                  user.SteamID,
+			"communityvisibilitystate": visibility,
+			"profilestate":             1,
+			"personaname":              user.PersonaName,
+			"profileurl":               fmt.Sprintf("https://steamcommunity.com/profiles/%s/", user.SteamID),
+			"avatar":                   user.Avatar,
+			"avatarmedium":             user.Avatar,
+			"avatarfull":               user.Avatar,
+			"personastate":             user.PersonaState,
+			"lastlogoff":               user.LastLogoff,
+		}
+		if !user.Private {
+			player["realname"] = user.RealName
+			player["timecreated"] = user.TimeCreated
+			player["loccountrycode"] = user.CountryCode
+		}
+		if app, ok := s.seed.app(user.InGame); ok {
+			player["gameid"] = strconv.Itoa(app.AppID)
+			player["gameextrainfo"] = app.Name
+		}
+		players = append(players, player)
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"response": map[string]interface{}{"players": players},
+	})
+}
+
+func (s *Server) handleFriendList(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.seed.user(r.URL.Query().Get("steamid"))
+	if !ok || user.Private {
+		// Steam answers private friend lists with a bare 401
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	friends := []map[string]interface{}{}
+	for i, id := range user.Friends {
+		friends = append(friends, map[string]interface{}{
+			"steamid":      id,
+			"relationship": "friend",
+			"friend_since": user.TimeCreated + int64(i+1)*90*24*3600,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"friendslist": map[string]interface{}{"friends": friends},
+	})
+}
+
+func (s *Server) handlePlayerBans(w http.ResponseWriter, r *http.Request) {
+	players := []map[string]interface{}{}
+	for _, id := range steamIDs(r) {
+		user, ok := s.seed.user(id)
+		if !ok {
+			continue
+		}
+		economyBan := user.Bans.EconomyBan
+		if economyBan == "" {
+			economyBan = "none"
+		}
+		players = append(players, map[string]interface{}{
+			"SteamId":          user.SteamID,
+			"CommunityBanned":  user.Bans.CommunityBanned,
+			"VACBanned":        user.Bans.VACBans > 0,
+			"NumberOfVACBans":  user.Bans.VACBans,
+			"DaysSinceLastBan": user.Bans.DaysSinceLastBan,
+			"NumberOfGameBans": user.Bans.GameBans,
+			"EconomyBan":       economyBan,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{"players": players})
+}
+
+func (s *Server) handleOwnedGames(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.seed.user(r.URL.Query().Get("steamid"))
+	if !ok || user.Private {
+		// Private libraries come back as an empty response
+		respondWithJSON(w, http.StatusOK, map[string]interface{}{"response": map[string]interface{}{}})
+		return
+	}
+
+	games := s.ownedGames(user, func(OwnedGame) bool { return true })
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"response": map[string]interface{}{
+			"game_count": len(games),
+			"games":      games,
+		},
+	})
+}
+
+func (s *Server) handleRecentlyPlayedGames(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.seed.user(r.URL.Query().Get("steamid"))
+	if !ok || user.Private {
+		respondWithJSON(w, http.StatusOK, map[string]interface{}{"response": map[string]interface{}{}})
+		return
+	}
+
+	games := s.ownedGames(user, func(game OwnedGame) bool { return game.Playtime2Weeks > 0 })
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"response": map[string]interface{}{
+			"total_count": len(games),
+			"games":       games,
+		},
+	})
+}
+
+func (s *Server) ownedGames(user User, keep func(OwnedGame) bool) []map[string]interface{} {
+	games := []map[string]interface{}{}
+	for _, game := range user.Games {
+		if !keep(game) {
+			continue
+		}
+		app, _ := s.seed.app(game.AppID)
+		entry := map[string]interface{}{
+			"appid":                       game.AppID,
+			"name":                        app.Name,
+			"playtime_forever":            game.PlaytimeForever,
+			"img_icon_url":                app.Icon,
+			"has_community_visible_stats": len(app.Achievements) > 0,
+			"rtime_last_played":           game.RtimeLastPlayed,
+		}
+		if game.Playtime2Weeks > 0 {
+			entry["playtime_2weeks"] = game.Playtime2Weeks
+		}
+		games = append(games, entry)
+	}
+	return games
+}
+
+func (s *Server) handleSteamLevel(w http.ResponseWriter, r *http.Request) {
+	user, _ := s.seed.user(r.URL.Query().Get("steamid"))
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"response": map[string]interface{}{"player_level": user.Level},
+	})
+}
+
+func (s *Server) handleBadges(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.seed.user(r.URL.Query().Get("steamid"))
+	if !ok || user.Private {
+		respondWithJSON(w, http.StatusOK, map[string]interface{}{"response": map[string]interface{}{}})
+		return
+	}
+
+	badges := []map[string]interface{}{}
+	for _, badge := range user.Badges {
+		entry := map[string]interface{}{
+			"badgeid":         badge.BadgeID,
+			"level":           badge.Level,
+			"completion_time": user.TimeCreated,
+			"xp":              badge.XP,
+			"scarcity":        1000,
+		}
+		if badge.AppID != 0 {
+			entry["appid"] = badge.AppID
+			entry["communityitemid"] = strconv.Itoa(badge.AppID*100 + badge.Level)
+			entry["border_color"] = 0
+		}
+		badges = append(badges, entry)
+	}
+
+	// Levels up to 10 take 100 XP each, every ten levels after cost 100 more
+	levelXP := func(level int) int {
+		xp := 0
+		for l := 1; l <= level; l++ {
+			xp += 100 * ((l-1)/10 + 1)
+		}
+		return xp
+	}
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"response": map[string]interface{}{
+			"badges":                         badges,
+			"player_xp":                      user.XP,
+			"player_level":                   user.Level,
+			"player_xp_needed_to_level_up":   levelXP(user.Level+1) - user.XP,
+			"player_xp_needed_current_level": levelXP(user.Level),
+		},
+	})
+}
+
+// Quests of the community badge
+const communityQuests = 28
+
+func (s *Server) handleCommunityBadgeProgress(w http.ResponseWriter, r *http.Request) {
+	user, _ := s.seed.user(r.URL.Query().Get("steamid"))
+
+	quests := []map[string]interface{}{}
+	for id := 1; id <= communityQuests; id++ {
+		quests = append(quests, map[string]interface{}{
+			"questid":   100 + id,
+			"completed": id <= user.QuestsDone,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"response": map[string]interface{}{"quests": quests},
+	})
+}
+
+func (s *Server) handlePlayerAchievements(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.seed.user(r.URL.Query().Get("steamid"))
+	app, hasApp := s.seed.app(intParam(r, "appid"))
+	if !ok || user.Private {
+		respondWithJSON(w, http.StatusForbidden, map[string]interface{}{
+			"playerstats": map[string]interface{}{"error": "Profile is not public", "success": false},
+		})
+		return
+	}
+	if !hasApp || len(app.Achievements) == 0 {
+		respondWithJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"playerstats": map[string]interface{}{"error": "Requested app has no stats", "success": false},
+		})
+		return
+	}
+
+	unlocked := map[string]bool{}
+	for _, name := range user.Achievements[app.AppID] {
+		unlocked[name] = true
+	}
+	lastPlayed := user.TimeCreated
+	for _, game := range user.Games {
+		if game.AppID == app.AppID {
+			lastPlayed = game.RtimeLastPlayed
+		}
+	}
+
+	achievements := []map[string]interface{}{}
+	for i, achievement := range app.Achievements {
+		entry := map[string]interface{}{
+			"apiname":    achievement.Name,
+			"achieved":   0,
+			"unlocktime": 0,
+		}
+		if unlocked[achievement.Name] {
+			entry["achieved"] = 1
+			entry["unlocktime"] = lastPlayed - int64(len(app.Achievements)-i)*3600
+		}
+		achievements = append(achievements, entry)
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"playerstats": map[string]interface{}{
+			"steamID":      user.SteamID,
+			"gameName":     app.Name,
+			"achievements": achievements,
+			"success":      true,
+		},
+	})
+}
+
+func (s *Server) handleSchemaForGame(w http.ResponseWriter, r *http.Request) {
+	app, ok := s.seed.app(intParam(r, "appid"))
+	if !ok {
+		respondWithJSON(w, http.StatusOK, map[string]interface{}{"game": map[string]interface{}{}})
+		return
+	}
+
+	achievements := []map[string]interface{}{}
+	for _, achievement := range app.Achievements {
+		hidden := 0
+		if achievement.Hidden {
+			hidden = 1
+		}
+		achievements = append(achievements, map[string]interface{}{
+			"name":         achievement.Name,
+			"defaultvalue": 0,
+			"displayName":  achievement.DisplayName,
+			"hidden":       hidden,
+			"description":  achievement.Description,
+			"icon":         fmt.Sprintf("https://cdn.fakesteam.local/apps/%d/%s.jpg", app.AppID, achievement.Name),
+			"icongray":     fmt.Sprintf("https://cdn.fakesteam.local/apps/%d/%s_gray.jpg", app.AppID, achievement.Name),
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"game": map[string]interface{}{
+			"gameName":    app.Name,
+			"gameVersion": "1",
+			"availableGameStats": map[string]interface{}{
+				"achievements": achievements,
+			},
+		},
+	})
+}
+
+func (s *Server) handleGlobalAchievementPercentages(w http.ResponseWriter, r *http.Request) {
+	app, _ := s.seed.app(intParam(r, "gameid"))
+
+	achievements := []map[string]interface{}{}
+	for _, achievement := range app.Achievements {
+		achievements = append(achievements, map[string]interface{}{
+			"name": achievement.Name,
+			// Steam sends the percentages as strings
+			"percent": strconv.FormatFloat(achievement.Percent, 'f', 1, 64),
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"achievementpercentages": map[string]interface{}{"achievements": achievements},
+	})
+}
+
+func (s *Server) handleCurrentPlayers(w http.ResponseWriter, r *http.Request) {
+	app, ok := s.seed.app(intParam(r, "appid"))
+	if !ok {
+		respondWithJSON(w, http.StatusNotFound, map[string]interface{}{
+			"response": map[string]interface{}{"result": 42},
+		})
+		return
+	}
+
+	// Drift around the seeded count, so sampled histories move
+	minute := time.Now().Minute()
+	count := app.Players + app.Players*(minute%13-6)/100
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"response": map[string]interface{}{"player_count": count, "result": 1},
+	})
+}
+
+func (s *Server) handleNews(w http.ResponseWriter, r *http.Request) {
+	app, _ := s.seed.app(intParam(r, "appid"))
+	count := intParam(r, "count")
+	if count <= 0 {
+		count = 20
+	}
+
+	news := append([]News(nil), app.News...)
+	sort.Slice(news, func(i, j int) bool { return news[i].Date > news[j].Date })
+	if len(news) > count {
+		news = news[:count]
+	}
+
+	items := []map[string]interface{}{}
+	for _, item := range news {
+		items = append(items, map[string]interface{}{
+			"gid":             item.Gid,
+			"title":           item.Title,
+			"url":             fmt.Sprintf("https://store.steampowered.com/news/app/%d/view/%s", app.AppID, item.Gid),
+			"is_external_url": false,
+			"author":          item.Author,
+			"contents":        item.Contents,
+			"feedlabel":       item.FeedLabel,
+			"date":            item.Date,
+			"feedname":        item.FeedName,
+			"feed_type":       item.FeedType,
+			"appid":           app.AppID,
+			"tags":            item.Tags,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"appnews": map[string]interface{}{
+			"appid":     app.AppID,
+			"newsitems": items,
+			"count":     len(app.News),
+		},
+	})
+}
+
+func (s *Server) handleAppList(w http.ResponseWriter, r *http.Request) {
+	apps := []map[string]interface{}{}
+	for _, app := range s.seed.Apps {
+		apps = append(apps, map[string]interface{}{"appid": app.AppID, "name": app.Name})
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"applist": map[string]interface{}{"apps": apps},
+	})
+}
+
+func (s *Server) handleWishlist(w http.ResponseWriter, r *http.Request) {
+	user, _ := s.seed.user(r.URL.Query().Get("steamid"))
+
+	items := []map[string]interface{}{}
+	for i, appID := range user.Wishlist {
+		items = append(items, map[string]interface{}{
+			"appid":      appID,
+			"priority":   i + 1,
+			"date_added": user.TimeCreated + int64(i+1)*30*24*3600,
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"response": map[string]interface{}{"items": items},
+	})
+}
@@ -0,0 +1,258 @@
+package fakesteam
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Names of the app inventories, as the profile page shows them
+var inventoryApps = map[int]struct {
+	Name     string
+	Contexts map[string]string
+}{
+	753: {"Steam", map[string]string{"6": "Community"}},
+	730: {"Counter-Strike 2", map[string]string{"2": "Backpack"}},
+	440: {"Team Fortress 2", map[string]string{"2": "Backpack"}},
+	570: {"Dota 2", map[string]string{"2": "Backpack"}},
+}
+
+const defaultInventoryCount = 2000
+
+type inventoryAsset struct {
+	Asset
+	AssetID string
+}
+
+// userAssets returns the assets of one inventory context. Asset IDs come
+// from the position in the seed, so they are stable between requests.
+func userAssets(user User, appID int, contextID string) []inventoryAsset {
+	var assets []inventoryAsset
+	for i, asset := range user.Inventory {
+		if asset.AppID != appID || asset.ContextID != contextID {
+			continue
+		}
+		assets = append(assets, inventoryAsset{
+			Asset:   asset,
+			AssetID: strconv.Itoa(10000000000 + i),
+		})
+	}
+	return assets
+}
+
+func itemDescription(item Item) map[string]interface{} {
+	description := map[string]interface{}{
+		"appid":                         item.AppID,
+		"classid":                       item.ClassID,
+		"instanceid":                    "0",
+		"currency":                      0,
+		"background_color":              "",
+		"icon_url":                      item.IconURL,
+		"descriptions":                  []interface{}{},
+		"tradable":                      item.Tradable,
+		"name":                          item.Name,
+		"type":                          item.Type,
+		"market_name":                   item.Name,
+		"market_hash_name":              item.MarketHashName,
+		"market_fee_app":                item.AppID,
+		"commodity":                     item.Commodity,
+		"market_tradable_restriction":   item.TradeHoldDays,
+		"market_marketable_restriction": 0,
+		"marketable":                    item.Marketable,
+		"tags":                          item.Tags,
+	}
+	return description
+}
+
+func (s *Server) handleInventory(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.seed.user(r.PathValue("steamid"))
+	appID, _ := strconv.Atoi(r.PathValue("appid"))
+	if !ok || user.Private {
+		// Private inventories answer a 403 with a null body
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("null"))
+		return
+	}
+
+	assets := userAssets(user, appID, r.PathValue("contextid"))
+	total := len(assets)
+
+	// Walk the start_assetid cursor
+	if start := r.URL.Query().Get("start_assetid"); start != "" {
+		for i, asset := range assets {
+			if asset.AssetID == start {
+				assets = assets[i+1:]
+				break
+			}
+		}
+	}
+	count := intParam(r, "count")
+	if count <= 0 || count > defaultInventoryCount {
+		count = defaultInventoryCount
+	}
+	more := len(assets) > count
+	if more {
+		assets = assets[:count]
+	}
+
+	rgAssets := []map[string]interface{}{}
+	descriptions := []map[string]interface{}{}
+	described := map[string]bool{}
+	for _, asset := range assets {
+		rgAssets = append(rgAssets, map[string]interface{}{
+			"appid":      asset.AppID,
+			"contextid":  asset.ContextID,
+			"assetid":    asset.AssetID,
+			"classid":    asset.ClassID,
+			"instanceid": "0",
+			"amount":     strconv.Itoa(asset.Amount),
+		})
+		if item, ok := s.seed.item(asset.AppID, asset.ClassID); ok && !described[asset.ClassID] {
+			described[asset.ClassID] = true
+			descriptions = append(descriptions, itemDescription(item))
+		}
+	}
+
+	page := map[string]interface{}{
+		"assets":                rgAssets,
+		"descriptions":          descriptions,
+		"total_inventory_count": total,
+		"success":               1,
+		"rwgrsn":                -2,
+	}
+	if more {
+		page["more_items"] = 1
+		page["last_assetid"] = assets[len(assets)-1].AssetID
+	}
+	respondWithJSON(w, http.StatusOK, page)
+}
+
+func (s *Server) handleTradeInventory(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.seed.user(r.URL.Query().Get("steamid"))
+	if !ok || user.Private {
+		respondWithJSON(w, http.StatusForbidden, map[string]interface{}{"response": map[string]interface{}{}})
+		return
+	}
+
+	assets := userAssets(user, intParam(r, "appid"), r.URL.Query().Get("contextid"))
+	items := []map[string]interface{}{}
+	for _, asset := range assets {
+		item, _ := s.seed.item(asset.AppID, asset.ClassID)
+		items = append(items, map[string]interface{}{
+			"appid":        asset.AppID,
+			"contextid":    asset.ContextID,
+			"assetid":      asset.AssetID,
+			"classid":      asset.ClassID,
+			"instanceid":   "0",
+			"amount":       strconv.Itoa(asset.Amount),
+			"descriptions": itemDescription(item),
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"response": map[string]interface{}{
+			"items":                 items,
+			"total_inventory_count": len(items),
+		},
+	})
+}
+
+func (s *Server) handleInventoryPage(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.seed.user(r.PathValue("steamid"))
+	if !ok {
+		http.Error(w, "The specified profile could not be found.", http.StatusNotFound)
+		return
+	}
+	if user.Private {
+		respondWithHTML(w, `<html><body><div class="profile_private_info">This profile is private.</div></body></html>`)
+		return
+	}
+
+	type context struct {
+		ID         string `json:"id"`
+		Name       string `json:"name"`
+		AssetCount int    `json:"asset_count"`
+	}
+	type appData struct {
+		AppID      int                 `json:"appid"`
+		Name       string              `json:"name"`
+		Icon       string              `json:"icon"`
+		AssetCount int                 `json:"asset_count"`
+		Contexts   map[string]*context `json:"rgContexts"`
+	}
+
+	apps := map[string]*appData{}
+	for _, asset := range user.Inventory {
+		known, ok := inventoryApps[asset.AppID]
+		if !ok {
+			continue
+		}
+		key := strconv.Itoa(asset.AppID)
+		app, ok := apps[key]
+		if !ok {
+			app = &appData{
+				AppID:    asset.AppID,
+				Name:     known.Name,
+				Icon:     fmt.Sprintf("https://cdn.fakesteam.local/apps/%d/icon.jpg", asset.AppID),
+				Contexts: map[string]*context{},
+			}
+			apps[key] = app
+		}
+		ctx, ok := app.Contexts[asset.ContextID]
+		if !ok {
+			ctx = &context{ID: asset.ContextID, Name: known.Contexts[asset.ContextID]}
+			app.Contexts[asset.ContextID] = ctx
+		}
+		ctx.AssetCount++
+		app.AssetCount++
+	}
+
+	data, _ := json.Marshal(apps)
+	respondWithHTML(w, fmt.Sprintf(`<html>
+<head><title>Steam Community :: %s :: Inventory</title></head>
+<body>
+<script type="text/javascript">
+	var g_rgAppContextData = %s;
+	var g_strInventoryLoadURL = 'https://steamcommunity.com/inventory/%s/';
+</script>
+</body>
+</html>`, user.PersonaName, data, user.SteamID))
+}
+
+func (s *Server) handleGameCardsPage(w http.ResponseWriter, r *http.Request) {
+	user, ok := s.seed.user(r.PathValue("steamid"))
+	appID, _ := strconv.Atoi(r.PathValue("appid"))
+	app, hasApp := s.seed.app(appID)
+	if !ok || !hasApp {
+		http.Error(w, "The specified profile could not be found.", http.StatusNotFound)
+		return
+	}
+
+	drops := "No card drops remaining"
+	if n := user.CardDrops[appID]; n > 0 {
+		drops = fmt.Sprintf("%d card drops remaining", n)
+		if n == 1 {
+			drops = "1 card drop remaining"
+		}
+	}
+
+	// The cards of the set, as the badge page lists them
+	var cards []string
+	for _, item := range s.cardSet(appID) {
+		cards = append(cards, fmt.Sprintf(`<div class="badge_card_set_text">%s</div>`, item.Name))
+	}
+	sort.Strings(cards)
+
+	respondWithHTML(w, fmt.Sprintf(`<html>
+<head><title>Steam Community :: %s :: Badges</title></head>
+<body>
+<div class="badge_title">%s Badge</div>
+<div class="badge_title_stats_drops"><span class="progress_info_bold">%s</span></div>
+<div class="badge_card_set_cards">%s</div>
+</body>
+</html>`, user.PersonaName, app.Name, drops, strings.Join(cards, "\n")))
+}
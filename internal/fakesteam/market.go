@@ -0,0 +1,356 @@
+package fakesteam
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Market currencies by Steam ID and their price level, as a percentage of
+// the seeded USD price
+var marketCurrencies = map[int]struct {
+	Code    string
+	Percent int
+}{
+	1: {"USD", 100}, 2: {"GBP", 80}, 3: {"EUR", 92}, 6: {"PLN", 400}, 7: {"BRL", 500},
+	8: {"JPY", 15000}, 20: {"CAD", 137}, 21: {"AUD", 152}, 24: {"INR", 8300}, 37: {"KZT", 45000},
+}
+
+// Days of price history on the listing pages
+const historyDays = 60
+
+// marketPrice converts a seeded USD price to the currency of the request.
+func marketPrice(r *http.Request, usd int) (string, int) {
+	currency, ok := marketCurrencies[intParam(r, "currency")]
+	if !ok {
+		currency = marketCurrencies[1]
+	}
+	return currency.Code, usd * currency.Percent / 100
+}
+
+// handleMarketHome hands out the session cookies the market pages expect.
+// Like on Steam, steamLoginSecure starts with the account signed in, and is
+// only set once someone is.
+func (s *Server) handleMarketHome(w http.ResponseWriter, r *http.Request) {
+	sessionID := make([]byte, 12)
+	rand.Read(sessionID)
+
+	s.mu.Lock()
+	signedIn := s.signedIn
+	s.mu.Unlock()
+
+	http.SetCookie(w, &http.Cookie{Name: "sessionid", Value: hex.EncodeToString(sessionID), Path: "/"})
+	if signedIn != "" {
+		http.SetCookie(w, &http.Cookie{Name: "steamLoginSecure", Value: signedIn + "%7C%7Cfakesteam", Path: "/", HttpOnly: true})
+	}
+	http.SetCookie(w, &http.Cookie{Name: "steamCountry", Value: "US%7Cfakesteam", Path: "/"})
+	respondWithHTML(w, `<html><head><title>Steam Community Market</title></head><body></body></html>`)
+}
+
+func (s *Server) handleListingPage(w http.ResponseWriter, r *http.Request) {
+	appID, _ := strconv.Atoi(r.PathValue("appid"))
+	item, ok := s.seed.itemByName(appID, r.PathValue("market_hash_name"))
+	if !ok {
+		respondWithHTML(w, `<html><body><div class="market_listing_table_message">There are no listings for this item.</div></body></html>`)
+		return
+	}
+
+	// One point a day, in the currency of the wallet, which is USD here
+	var history [][]interface{}
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+	for i := historyDays; i > 0; i-- {
+		price := item.Price * (100 + (i*7)%11 - 5) / 100
+		history = append(history, []interface{}{
+			day.AddDate(0, 0, -i).Format("Jan 02 2006 15: +0"),
+			float64(price) / 100,
+			strconv.Itoa(item.Volume*(90+i%20)/100 + 1),
+		})
+	}
+	line1, _ := json.Marshal(history)
+
+	respondWithHTML(w, fmt.Sprintf(`<html>
+<head><title>Steam Community Market :: Listings for %s</title></head>
+<body>
+<script type="text/javascript">
+	var line1=%s;
+	g_timePriceHistoryEarliest = new Date();
+	$J(function() {
+		Market_LoadOrderSpread( %d );	// initial load
+	});
+</script>
+</body>
+</html>`, item.Name, line1, item.NameID))
+}
+
+func (s *Server) handleListingsRender(w http.ResponseWriter, r *http.Request) {
+	appID, _ := strconv.Atoi(r.PathValue("appid"))
+	item, ok := s.seed.itemByName(appID, r.PathValue("market_hash_name"))
+	if !ok {
+		respondWithJSON(w, http.StatusOK, map[string]interface{}{"success": false})
+		return
+	}
+
+	start := intParam(r, "start")
+	count := intParam(r, "count")
+	if count <= 0 || count > 100 {
+		count = 10
+	}
+	currencyID := intParam(r, "currency")
+	if _, ok := marketCurrencies[currencyID]; !ok {
+		currencyID = 1
+	}
+
+	// Listings go up from the lowest price, sellers being paid the price
+	// without the Steam and publisher fees
+	step := max(item.Price/50, 1)
+	listings := map[string]interface{}{}
+	for i := start; i < min(start+count, item.Listings); i++ {
+		price := sellerAmount(item.Price + i*step)
+		steamFee, publisherFee := marketFees(price)
+		_, converted := marketPrice(r, price)
+		convertedSteamFee, convertedPublisherFee := marketFees(converted)
+
+		listingID := strconv.Itoa(item.NameID*1000 + i)
+		listings[listingID] = map[string]interface{}{
+			"listingid":               listingID,
+			"price":                   price,
+			"fee":                     steamFee + publisherFee,
+			"steam_fee":               steamFee,
+			"publisher_fee":           publisherFee,
+			"publisher_fee_app":       item.AppID,
+			"currencyid":              2001,
+			"converted_price":         converted,
+			"converted_fee":           convertedSteamFee + convertedPublisherFee,
+			"converted_steam_fee":     convertedSteamFee,
+			"converted_publisher_fee": convertedPublisherFee,
+			"converted_currencyid":    2000 + currencyID,
+			"asset": map[string]interface{}{
+				"currency":  0,
+				"appid":     item.AppID,
+				"contextid": "2",
+				"id":        strconv.Itoa(20000000000 + item.NameID%1000*1000 + i),
+				"amount":    "1",
+			},
+		}
+	}
+
+	// PHP sends empty maps as arrays
+	var listingInfo interface{} = listings
+	if len(listings) == 0 {
+		listingInfo = []interface{}{}
+	}
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success":      true,
+		"start":        start,
+		"pagesize":     count,
+		"total_count":  item.Listings,
+		"results_html": "",
+		"listinginfo":  listingInfo,
+		"assets":       map[string]interface{}{},
+		"currency":     []interface{}{},
+		"hovers":       "",
+	})
+}
+
+// marketFees returns the Steam and publisher fees of a sale, 5% and 10% of
+// what the seller receives, at least one cent each.
+func marketFees(amount int) (int, int) {
+	return max(amount*5/100, 1), max(amount*10/100, 1)
+}
+
+// sellerAmount returns what the seller of a listing gets for a buyer price.
+func sellerAmount(buyerPrice int) int {
+	amount := buyerPrice * 100 / 115
+	for {
+		steamFee, publisherFee := marketFees(amount + 1)
+		if amount+1+steamFee+publisherFee > buyerPrice {
+			return amount
+		}
+		amount++
+	}
+}
+
+func (s *Server) handleOrderHistogram(w http.ResponseWriter, r *http.Request) {
+	var item Item
+	found := false
+	for _, candidate := range s.seed.Items {
+		if strconv.Itoa(candidate.NameID) == r.URL.Query().Get("item_nameid") {
+			item, found = candidate, true
+		}
+	}
+	if !found {
+		respondWithJSON(w, http.StatusOK, map[string]interface{}{"success": 9})
+		return
+	}
+
+	code, lowest := marketPrice(r, item.Price)
+	step := lowest / 20
+	if step < 1 {
+		step = 1
+	}
+	prefix, suffix := pricePrefixSuffix(code)
+
+	// Five price levels on each side, with cumulative quantities
+	sells := [][]interface{}{}
+	buys := [][]interface{}{}
+	sellTotal, buyTotal := 0, 0
+	for level := 0; level < 5; level++ {
+		sellPrice := lowest + level*step
+		sellTotal += item.Listings/5 + level + 1
+		sells = append(sells, []interface{}{
+			float64(sellPrice) / 100, sellTotal,
+			fmt.Sprintf("%d sell orders at %s or lower", sellTotal, formatPrice(code, sellPrice)),
+		})
+
+		buyPrice := lowest - (level+1)*step
+		if buyPrice <= 0 {
+			break
+		}
+		buyTotal += item.Volume/4 + level*2 + 1
+		buys = append(buys, []interface{}{
+			float64(buyPrice) / 100, buyTotal,
+			fmt.Sprintf("%d buy orders at %s or higher", buyTotal, formatPrice(code, buyPrice)),
+		})
+	}
+
+	highestBuy := ""
+	if len(buys) > 0 {
+		highestBuy = strconv.Itoa(lowest - step)
+	}
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success":            1,
+		"sell_order_table":   "",
+		"sell_order_summary": fmt.Sprintf("<span class=\"market_commodity_orders_header_promote\">%d</span> for sale starting at <span class=\"market_commodity_orders_header_promote\">%s</span>", sellTotal, formatPrice(code, lowest)),
+		"buy_order_table":    "",
+		"buy_order_summary":  fmt.Sprintf("<span class=\"market_commodity_orders_header_promote\">%d</span> requests to buy", buyTotal),
+		"highest_buy_order":  highestBuy,
+		"lowest_sell_order":  strconv.Itoa(lowest),
+		"buy_order_graph":    buys,
+		"sell_order_graph":   sells,
+		"graph_max_y":        sellTotal,
+		"graph_min_x":        float64(lowest-5*step) / 100,
+		"graph_max_x":        float64(lowest+5*step) / 100,
+		"price_prefix":       prefix,
+		"price_suffix":       suffix,
+	})
+}
+
+func pricePrefixSuffix(code string) (string, string) {
+	formatted := formatPrice(code, 0)
+	i := strings.IndexAny(formatted, "0123456789")
+	j := strings.LastIndexAny(formatted, "0123456789")
+	return strings.TrimSpace(formatted[:i]), strings.TrimSpace(formatted[j+1:])
+}
+
+func (s *Server) handlePriceOverview(w http.ResponseWriter, r *http.Request) {
+	item, ok := s.seed.itemByName(intParam(r, "appid"), r.URL.Query().Get("market_hash_name"))
+	if !ok || item.Marketable == 0 {
+		// Unknown items answer a 500 with success false
+		respondWithJSON(w, http.StatusInternalServerError, map[string]interface{}{"success": false})
+		return
+	}
+
+	code, lowest := marketPrice(r, item.Price)
+	_, median := marketPrice(r, item.Price*105/100)
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success":      true,
+		"lowest_price": formatPrice(code, lowest),
+		"volume":       formatThousands(item.Volume),
+		"median_price": formatPrice(code, median),
+	})
+}
+
+func (s *Server) handleMarketSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	appID := intParam(r, "appid")
+
+	results := []map[string]interface{}{}
+	for _, item := range s.seed.Items {
+		if appID != 0 && item.AppID != appID {
+			continue
+		}
+		if text := query.Get("query"); text != "" && !strings.Contains(strings.ToLower(item.Name), strings.ToLower(text)) {
+			continue
+		}
+		if !matchesCategories(item, query, appID) {
+			continue
+		}
+
+		results = append(results, map[string]interface{}{
+			"name":            item.Name,
+			"hash_name":       item.MarketHashName,
+			"sell_listings":   item.Listings,
+			"sell_price":      item.Price,
+			"sell_price_text": formatPrice("USD", item.Price),
+			"asset_description": map[string]interface{}{
+				"appid":    item.AppID,
+				"classid":  item.ClassID,
+				"icon_url": item.IconURL,
+				"name":     item.Name,
+				"type":     item.Type,
+			},
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success":     true,
+		"start":       0,
+		"pagesize":    len(results),
+		"total_count": len(results),
+		"results":     results,
+	})
+}
+
+// matchesCategories applies the category_<appid>_<category>[]=tag_<name>
+// filters of a market search.
+func matchesCategories(item Item, query map[string][]string, appID int) bool {
+	prefix := fmt.Sprintf("category_%d_", appID)
+	for key, values := range query {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		category := strings.TrimSuffix(strings.TrimPrefix(key, prefix), "[]")
+		for _, value := range values {
+			if !hasTag(item, category, strings.TrimPrefix(value, "tag_")) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func hasTag(item Item, category, internalName string) bool {
+	for _, tag := range item.Tags {
+		if tag.Category == category && tag.InternalName == internalName {
+			return true
+		}
+	}
+	return false
+}
+
+// cardSet returns the normal trading cards of a game.
+func (s *Server) cardSet(appID int) []Item {
+	var cards []Item
+	for _, item := range s.seed.Items {
+		if item.AppID == 753 &&
+			hasTag(item, "Game", fmt.Sprintf("app_%d", appID)) &&
+			hasTag(item, "item_class", "item_class_2") &&
+			hasTag(item, "cardborder", "cardborder_0") {
+			cards = append(cards, item)
+		}
+	}
+	return cards
+}
+
+func formatThousands(n int) string {
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
@@ -0,0 +1,101 @@
+package fakesteam
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	openIDNs       = "http://specs.openid.net/auth/2.0"
+	claimedIDBase  = "https://steamcommunity.com/openid/id/"
+	openIDEndpoint = "https://steamcommunity.com/openid/login"
+	openIDSig      = "fakesteam"
+)
+
+// handleLoginPage stands in for the Steam sign in page: it lists the seeded
+// users and signs in as the one picked, redirecting back like Steam does.
+func (s *Server) handleLoginPage(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	returnTo := query.Get("openid.return_to")
+	if returnTo == "" {
+		http.Error(w, "Missing openid.return_to", http.StatusBadRequest)
+		return
+	}
+
+	if steamID := query.Get("steamid"); steamID != "" {
+		if _, ok := s.seed.user(steamID); !ok {
+			http.Error(w, "Unknown user", http.StatusNotFound)
+			return
+		}
+		http.Redirect(w, r, assertionURL(returnTo, steamID), http.StatusFound)
+		return
+	}
+
+	var links strings.Builder
+	for _, user := range s.seed.Users {
+		pick := *r.URL
+		q := pick.Query()
+		q.Set("steamid", user.SteamID)
+		pick.RawQuery = q.Encode()
+		fmt.Fprintf(&links, "\t<li><a href=\"%s\">%s</a> (%s)</li>\n",
+			html.EscapeString(pick.String()), html.EscapeString(user.PersonaName), user.SteamID)
+	}
+
+	respondWithHTML(w, fmt.Sprintf(`<html>
+<head><title>Sign In (fake Steam)</title></head>
+<body>
+<h1>Sign in as a demo user</h1>
+<ul>
+%s</ul>
+</body>
+</html>`, links.String()))
+}
+
+// assertionURL is the positive assertion Steam redirects to after signing in.
+func assertionURL(returnTo, steamID string) string {
+	u, err := url.Parse(returnTo)
+	if err != nil {
+		return returnTo
+	}
+
+	q := u.Query()
+	q.Set("openid.ns", openIDNs)
+	q.Set("openid.mode", "id_res")
+	q.Set("openid.op_endpoint", openIDEndpoint)
+	q.Set("openid.claimed_id", claimedIDBase+steamID)
+	q.Set("openid.identity", claimedIDBase+steamID)
+	q.Set("openid.return_to", returnTo)
+	q.Set("openid.response_nonce", time.Now().UTC().Format("2006-01-02T15:04:05Z")+strconv.Itoa(time.Now().Nanosecond()))
+	q.Set("openid.assoc_handle", "1234567890")
+	q.Set("openid.signed", "signed,op_endpoint,claimed_id,identity,return_to,response_nonce,assoc_handle")
+	q.Set("openid.sig", openIDSig)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// handleCheckAuthentication validates the assertions signed by the login
+// page.
+func (s *Server) handleCheckAuthentication(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form", http.StatusBadRequest)
+		return
+	}
+
+	steamID := strings.TrimPrefix(r.PostForm.Get("openid.claimed_id"), claimedIDBase)
+	_, known := s.seed.user(steamID)
+	valid := r.PostForm.Get("openid.mode") == "check_authentication" &&
+		r.PostForm.Get("openid.sig") == openIDSig && known
+	if valid {
+		s.mu.Lock()
+		s.signedIn = steamID
+		s.mu.Unlock()
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintf(w, "ns:%s\nis_valid:%t\n", openIDNs, valid)
+}
@@ -0,0 +1,208 @@
+package fakesteam
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+//go:embed seed.json
+var defaultSeed []byte
+
+// Seed is the data the fake server answers with: its users, the apps of the
+// store and the items of the community market.
+type Seed struct {
+	Users []User `json:"users"`
+	Apps  []App  `json:"apps"`
+	Items []Item `json:"items"`
+}
+
+type User struct {
+	SteamID      string           `json:"steamid"`
+	PersonaName  string           `json:"personaname"`
+	RealName     string           `json:"realname"`
+	Avatar       string           `json:"avatar"`
+	TimeCreated  int64            `json:"timecreated"`
+	PersonaState int              `json:"personastate"`
+	Private      bool             `json:"private"`
+	InGame       int              `json:"in_game"`
+	Level        int              `json:"level"`
+	XP           int              `json:"xp"`
+	QuestsDone   int              `json:"quests_done"`
+	Friends      []string         `json:"friends"`
+	Games        []OwnedGame      `json:"games"`
+	Wishlist     []int            `json:"wishlist"`
+	Achievements map[int][]string `json:"achievements"`
+	Badges       []Badge          `json:"badges"`
+	CardDrops    map[int]int      `json:"card_drops"`
+	Inventory    []Asset          `json:"inventory"`
+	Bans         Bans             `json:"bans"`
+	CountryCode  string           `json:"loccountrycode"`
+	LastLogoff   int64            `json:"lastlogoff"`
+}
+
+type OwnedGame struct {
+	AppID           int   `json:"appid"`
+	PlaytimeForever int   `json:"playtime_forever"`
+	Playtime2Weeks  int   `json:"playtime_2weeks"`
+	RtimeLastPlayed int64 `json:"rtime_last_played"`
+}
+
+type Badge struct {
+	BadgeID int `json:"badgeid"`
+	AppID   int `json:"appid"`
+	Level   int `json:"level"`
+	XP      int `json:"xp"`
+}
+
+type Asset struct {
+	AppID     int    `json:"appid"`
+	ContextID string `json:"contextid"`
+	ClassID   string `json:"classid"`
+	Amount    int    `json:"amount"`
+}
+
+type Bans struct {
+	CommunityBanned  bool   `json:"community_banned"`
+	VACBans          int    `json:"vac_bans"`
+	GameBans         int    `json:"game_bans"`
+	DaysSinceLastBan int    `json:"days_since_last_ban"`
+	EconomyBan       string `json:"economy_ban"`
+}
+
+type App struct {
+	AppID        int           `json:"appid"`
+	Name         string        `json:"name"`
+	Type         string        `json:"type"`
+	IsFree       bool          `json:"is_free"`
+	Icon         string        `json:"icon"`
+	ShortDesc    string        `json:"short_description"`
+	ReleaseDate  string        `json:"release_date"`
+	ComingSoon   bool          `json:"coming_soon"`
+	Price        *Price        `json:"price"`
+	Categories   []Category    `json:"categories"`
+	Genres       []Genre       `json:"genres"`
+	Players      int           `json:"players"`
+	Achievements []Achievement `json:"achievements"`
+	News         []News        `json:"news"`
+}
+
+type Price struct {
+	Currency        string `json:"currency"`
+	Initial         int    `json:"initial"`
+	DiscountPercent int    `json:"discount_percent"`
+}
+
+type Category struct {
+	ID          int    `json:"id"`
+	Description string `json:"description"`
+}
+
+type Genre struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+}
+
+type Achievement struct {
+	Name        string  `json:"name"`
+	DisplayName string  `json:"display_name"`
+	Description string  `json:"description"`
+	Hidden      bool    `json:"hidden"`
+	Percent     float64 `json:"percent"`
+}
+
+type News struct {
+	Gid       string   `json:"gid"`
+	Title     string   `json:"title"`
+	Author    string   `json:"author"`
+	Contents  string   `json:"contents"`
+	FeedName  string   `json:"feedname"`
+	FeedLabel string   `json:"feedlabel"`
+	FeedType  int      `json:"feed_type"`
+	Date      int64    `json:"date"`
+	Tags      []string `json:"tags"`
+}
+
+type Item struct {
+	AppID          int    `json:"appid"`
+	ClassID        string `json:"classid"`
+	NameID         int    `json:"item_nameid"`
+	Name           string `json:"name"`
+	MarketHashName string `json:"market_hash_name"`
+	Type           string `json:"type"`
+	IconURL        string `json:"icon_url"`
+	Tradable       int    `json:"tradable"`
+	Marketable     int    `json:"marketable"`
+	Commodity      int    `json:"commodity"`
+	TradeHoldDays  int    `json:"market_tradable_restriction"`
+	Tags           []Tag  `json:"tags"`
+	Price          int    `json:"price"`  // Lowest listing, in USD cents
+	Volume         int    `json:"volume"` // Sales in the last 24 hours
+	Listings       int    `json:"listings"`
+}
+
+type Tag struct {
+	Category              string `json:"category"`
+	InternalName          string `json:"internal_name"`
+	LocalizedCategoryName string `json:"localized_category_name"`
+	LocalizedTagName      string `json:"localized_tag_name"`
+}
+
+// DefaultSeed returns the demo data shipped with the package.
+func DefaultSeed() (*Seed, error) {
+	return parseSeed(defaultSeed)
+}
+
+// LoadSeed reads the seed from a JSON file.
+func LoadSeed(path string) (*Seed, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading seed: %w", err)
+	}
+	return parseSeed(data)
+}
+
+func parseSeed(data []byte) (*Seed, error) {
+	var seed Seed
+	if err := json.Unmarshal(data, &seed); err != nil {
+		return nil, fmt.Errorf("decoding seed: %w", err)
+	}
+	return &seed, nil
+}
+
+func (s *Seed) user(steamID string) (User, bool) {
+	for _, user := range s.Users {
+		if user.SteamID == steamID {
+			return user, true
+		}
+	}
+	return User{}, false
+}
+
+func (s *Seed) app(appID int) (App, bool) {
+	for _, app := range s.Apps {
+		if app.AppID == appID {
+			return app, true
+		}
+	}
+	return App{}, false
+}
+
+func (s *Seed) item(appID int, classID string) (Item, bool) {
+	for _, item := range s.Items {
+		if item.AppID == appID && item.ClassID == classID {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
+
+func (s *Seed) itemByName(appID int, marketHashName string) (Item, bool) {
+	for _, item := range s.Items {
+		if item.AppID == appID && item.MarketHashName == marketHashName {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
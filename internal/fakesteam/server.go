@@ -0,0 +1,139 @@
+// Package fakesteam simulates the parts of Steam gamehub talks to: the Web
+// API, the store, community inventories and profiles, the market and the
+// OpenID login. It answers from seed data, so gamehub can run without a
+// Steam account, an API key or network access.
+package fakesteam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	apiHost       = "api.steampowered.com"
+	storeHost     = "store.steampowered.com"
+	communityHost = "steamcommunity.com"
+)
+
+// Server routes requests by the Steam host they were meant for, so a client
+// only has to send them to its address keeping the original Host header.
+type Server struct {
+	seed *Seed
+	mux  *http.ServeMux
+	URL  string
+
+	mu       sync.Mutex
+	signedIn string // Last user signed in, the owner of the market session
+}
+
+func New(seed *Seed) *Server {
+	s := &Server{
+		seed: seed,
+		mux:  http.NewServeMux(),
+	}
+	s.routes()
+	return s
+}
+
+func (s *Server) routes() {
+	// Web API
+	s.handle(apiHost, "/ISteamUser/GetPlayerSummaries/v0002/", s.handlePlayerSummaries)
+	s.handle(apiHost, "/ISteamUser/GetFriendList/v1/", s.handleFriendList)
+	s.handle(apiHost, "/ISteamUser/GetPlayerBans/v1/", s.handlePlayerBans)
+	s.handle(apiHost, "/IPlayerService/GetOwnedGames/v1/", s.handleOwnedGames)
+	s.handle(apiHost, "/IPlayerService/GetRecentlyPlayedGames/v1/", s.handleRecentlyPlayedGames)
+	s.handle(apiHost, "/IPlayerService/GetSteamLevel/v1/", s.handleSteamLevel)
+	s.handle(apiHost, "/IPlayerService/GetBadges/v1/", s.handleBadges)
+	s.handle(apiHost, "/IPlayerService/GetCommunityBadgeProgress/v1/", s.handleCommunityBadgeProgress)
+	s.handle(apiHost, "/ISteamUserStats/GetPlayerAchievements/v1/", s.handlePlayerAchievements)
+	s.handle(apiHost, "/ISteamUserStats/GetSchemaForGame/v2/", s.handleSchemaForGame)
+	s.handle(apiHost, "/ISteamUserStats/GetGlobalAchievementPercentagesForApp/v2/", s.handleGlobalAchievementPercentages)
+	s.handle(apiHost, "/ISteamUserStats/GetNumberOfCurrentPlayers/v1/", s.handleCurrentPlayers)
+	s.handle(apiHost, "/ISteamNews/GetNewsForApp/v2/", s.handleNews)
+	s.handle(apiHost, "/ISteamApps/GetAppList/v2/", s.handleAppList)
+	s.handle(apiHost, "/IWishlistService/GetWishlist/v1/", s.handleWishlist)
+	s.handle(apiHost, "/IEconService/GetInventoryItemsWithDescriptions/v1/", s.handleTradeInventory)
+
+	// Store
+	s.handle(storeHost, "/api/appdetails", s.handleAppDetails)
+
+	// Community
+	s.handle(communityHost, "/inventory/{steamid}/{appid}/{contextid}", s.handleInventory)
+	s.handle(communityHost, "/profiles/{steamid}/inventory/", s.handleInventoryPage)
+	s.handle(communityHost, "/profiles/{steamid}/gamecards/{appid}/", s.handleGameCardsPage)
+	s.handle(communityHost, "/market/{$}", s.handleMarketHome)
+	s.handle(communityHost, "/market/listings/{appid}/{market_hash_name}", s.handleListingPage)
+	s.handle(communityHost, "/market/listings/{appid}/{market_hash_name}/render/", s.handleListingsRender)
+	s.handle(communityHost, "/market/itemordershistogram", s.handleOrderHistogram)
+	s.handle(communityHost, "/market/priceoverview/", s.handlePriceOverview)
+	s.handle(communityHost, "/market/search/render/", s.handleMarketSearch)
+
+	// OpenID, opened by the browser on the fake server address itself
+	s.mux.HandleFunc("GET /openid/login", s.handleLoginPage)
+	s.mux.HandleFunc("POST /openid/login", s.handleCheckAuthentication)
+}
+
+func (s *Server) handle(host, path string, handler http.HandlerFunc) {
+	s.mux.HandleFunc("GET "+host+path, handler)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Start serves on addr in the background and sets URL. An addr with port 0
+// picks a free port.
+func (s *Server) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("starting fake steam: %w", err)
+	}
+	s.URL = "http://" + listener.Addr().String()
+
+	go func() {
+		if err := http.Serve(listener, s); err != nil && !errors.Is(err, net.ErrClosed) {
+			log.Printf("Fake steam stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	w.Write(data)
+}
+
+func respondWithHTML(w http.ResponseWriter, page string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, page)
+}
+
+// steamIDs reads a comma-separated steamids parameter.
+func steamIDs(r *http.Request) []string {
+	var ids []string
+	for _, id := range strings.Split(r.URL.Query().Get("steamids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func intParam(r *http.Request, name string) int {
+	n, _ := strconv.Atoi(r.URL.Query().Get(name))
+	return n
+}
@@ -0,0 +1,175 @@
+package fakesteam
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/masintxi/gamehub/internal/client"
+)
+
+const (
+	demoUser    = "76561198000000001"
+	privateUser = "76561198000000003"
+)
+
+// newFakeClient starts the fake Steam with the default seed and returns a
+// client that sends every Steam URL to it.
+func newFakeClient(t *testing.T) (*http.Client, *httptest.Server) {
+	t.Helper()
+
+	seed, err := DefaultSeed()
+	if err != nil {
+		t.Fatalf("loading seed: %v", err)
+	}
+	server := httptest.NewServer(New(seed))
+	t.Cleanup(server.Close)
+
+	target, _ := url.Parse(server.URL)
+	return &http.Client{
+		Transport: client.Chain(http.DefaultTransport, client.Upstream(target)),
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}, server
+}
+
+func getJSON(t *testing.T, c *http.Client, target string, v interface{}) int {
+	t.Helper()
+
+	resp, err := c.Get(target)
+	if err != nil {
+		t.Fatalf("GET %s: %v", target, err)
+	}
+	defer resp.Body.Close()
+
+	if v != nil && resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("decoding %s: %v", target, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestOwnedGames(t *testing.T) {
+	c, _ := newFakeClient(t)
+
+	var owned struct {
+		Response struct {
+			GameCount int `json:"game_count"`
+			Games     []struct {
+				AppID int    `json:"appid"`
+				Name  string `json:"name"`
+			} `json:"games"`
+		} `json:"response"`
+	}
+	status := getJSON(t, c, "https://api.steampowered.com/IPlayerService/GetOwnedGames/v1/?key=demo&steamid="+demoUser+"&include_appinfo=true", &owned)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if owned.Response.GameCount == 0 || owned.Response.GameCount != len(owned.Response.Games) {
+		t.Fatalf("expected games, got %+v", owned.Response)
+	}
+	if owned.Response.Games[0].Name == "" {
+		t.Errorf("expected app info, got %+v", owned.Response.Games[0])
+	}
+
+	// Private profiles answer an empty response
+	owned.Response.GameCount, owned.Response.Games = 0, nil
+	getJSON(t, c, "https://api.steampowered.com/IPlayerService/GetOwnedGames/v1/?key=demo&steamid="+privateUser, &owned)
+	if owned.Response.GameCount != 0 {
+		t.Errorf("expected no games of a private profile, got %d", owned.Response.GameCount)
+	}
+}
+
+func TestInventoryPages(t *testing.T) {
+	c, _ := newFakeClient(t)
+
+	type page struct {
+		Assets []struct {
+			AssetID string `json:"assetid"`
+		} `json:"assets"`
+		Descriptions []struct {
+			MarketHashName string `json:"market_hash_name"`
+		} `json:"descriptions"`
+		Total       int    `json:"total_inventory_count"`
+		MoreItems   int    `json:"more_items"`
+		LastAssetID string `json:"last_assetid"`
+	}
+
+	base := "https://steamcommunity.com/inventory/" + demoUser + "/753/6?l=english&count=4"
+	var first page
+	getJSON(t, c, base, &first)
+	if len(first.Assets) != 4 || first.MoreItems != 1 || first.LastAssetID == "" {
+		t.Fatalf("expected a first page of 4 with more items, got %+v", first)
+	}
+
+	var second page
+	getJSON(t, c, base+"&start_assetid="+first.LastAssetID, &second)
+	if len(first.Assets)+len(second.Assets) != first.Total || second.MoreItems != 0 {
+		t.Errorf("expected the rest of %d assets, got %d more", first.Total, len(second.Assets))
+	}
+	if len(second.Descriptions) == 0 || second.Descriptions[0].MarketHashName == "" {
+		t.Errorf("expected descriptions, got %+v", second.Descriptions)
+	}
+
+	if status := getJSON(t, c, "https://steamcommunity.com/inventory/"+privateUser+"/753/6", nil); status != http.StatusForbidden {
+		t.Errorf("expected status 403 for a private inventory, got %d", status)
+	}
+}
+
+func TestListingPage(t *testing.T) {
+	c, _ := newFakeClient(t)
+
+	resp, err := c.Get("https://steamcommunity.com/market/listings/730/" + url.PathEscape("Revolution Case"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	for _, want := range []string{"var line1=[[", "Market_LoadOrderSpread( 176000103 )"} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("expected listing page to contain %q", want)
+		}
+	}
+}
+
+func TestOpenIDLogin(t *testing.T) {
+	c, server := newFakeClient(t)
+
+	returnTo := "http://localhost:8080/auth/steam/callback"
+	resp, err := c.Get(server.URL + "/openid/login?steamid=" + demoUser + "&openid.return_to=" + url.QueryEscape(returnTo))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("expected a redirect, got %d", resp.StatusCode)
+	}
+
+	assertion, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil || !strings.HasPrefix(assertion.String(), returnTo) {
+		t.Fatalf("expected a redirect to %s, got %s", returnTo, resp.Header.Get("Location"))
+	}
+
+	// Check the assertion the way the callback does
+	form := url.Values{}
+	for key, values := range assertion.Query() {
+		form[key] = values
+	}
+	form.Set("openid.mode", "check_authentication")
+	resp, err = c.PostForm(server.URL+"/openid/login", form)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "is_valid:true") {
+		t.Errorf("expected a valid assertion, got %q", body)
+	}
+}
@@ -0,0 +1,120 @@
+package fakesteam
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Store currencies of each country and their price level, as a percentage of
+// the seeded US price in minor units
+var regionalPricing = map[string]struct {
+	Currency string
+	Percent  int
+}{
+	"us": {"USD", 100}, "gb": {"GBP", 85}, "de": {"EUR", 95}, "es": {"EUR", 95},
+	"fr": {"EUR", 95}, "it": {"EUR", 95}, "nl": {"EUR", 95}, "pl": {"PLN", 380},
+	"tr": {"USD", 55}, "ar": {"USD", 60}, "br": {"BRL", 320}, "in": {"INR", 4200},
+	"jp": {"JPY", 12500}, "au": {"AUD", 150}, "ca": {"CAD", 135}, "kz": {"KZT", 26000},
+}
+
+func (s *Server) handleAppDetails(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	cc := strings.ToLower(query.Get("cc"))
+	onlyPrice := query.Get("filters") == "price_overview"
+
+	result := map[string]interface{}{}
+	for _, id := range strings.Split(query.Get("appids"), ",") {
+		appID, err := strconv.Atoi(strings.TrimSpace(id))
+		if err != nil {
+			continue
+		}
+
+		app, ok := s.seed.app(appID)
+		if !ok {
+			result[id] = map[string]interface{}{"success": false}
+			continue
+		}
+
+		price := regionalPrice(app, cc)
+		if onlyPrice {
+			// Free and unpriced apps come back with an empty array
+			var data interface{} = []interface{}{}
+			if price != nil {
+				data = map[string]interface{}{"price_overview": price}
+			}
+			result[id] = map[string]interface{}{"success": true, "data": data}
+			continue
+		}
+
+		data := map[string]interface{}{
+			"type":              app.Type,
+			"name":              app.Name,
+			"steam_appid":       app.AppID,
+			"is_free":           app.IsFree,
+			"short_description": app.ShortDesc,
+			"header_image":      fmt.Sprintf("https://cdn.fakesteam.local/apps/%d/header.jpg", app.AppID),
+			"categories":        app.Categories,
+			"genres":            app.Genres,
+			"release_date": map[string]interface{}{
+				"coming_soon": app.ComingSoon,
+				"date":        app.ReleaseDate,
+			},
+		}
+		if price != nil {
+			data["price_overview"] = price
+		}
+		result[id] = map[string]interface{}{"success": true, "data": data}
+	}
+
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+func regionalPrice(app App, cc string) map[string]interface{} {
+	if app.Price == nil || app.IsFree {
+		return nil
+	}
+
+	region, ok := regionalPricing[cc]
+	if !ok {
+		region = regionalPricing["us"]
+	}
+
+	initial := app.Price.Initial * region.Percent / 100
+	final := initial * (100 - app.Price.DiscountPercent) / 100
+	return map[string]interface{}{
+		"currency":          region.Currency,
+		"initial":           initial,
+		"final":             final,
+		"discount_percent":  app.Price.DiscountPercent,
+		"initial_formatted": formatPrice(region.Currency, initial),
+		"final_formatted":   formatPrice(region.Currency, final),
+	}
+}
+
+// formatPrice writes an amount in minor units the way Steam shows it.
+func formatPrice(currency string, amount int) string {
+	whole, cents := amount/100, amount%100
+	switch currency {
+	case "EUR":
+		return fmt.Sprintf("%d,%02d€", whole, cents)
+	case "GBP":
+		return fmt.Sprintf("£%d.%02d", whole, cents)
+	case "PLN":
+		return fmt.Sprintf("%d,%02dzł", whole, cents)
+	case "BRL":
+		return fmt.Sprintf("R$ %d,%02d", whole, cents)
+	case "INR":
+		return fmt.Sprintf("₹ %d", whole)
+	case "JPY":
+		return fmt.Sprintf("¥ %d", whole)
+	case "AUD":
+		return fmt.Sprintf("A$ %d.%02d", whole, cents)
+	case "CAD":
+		return fmt.Sprintf("CDN$ %d.%02d", whole, cents)
+	case "KZT":
+		return fmt.Sprintf("%d₸", whole)
+	}
+	return fmt.Sprintf("$%d.%02d", whole, cents)
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Kinds of failure. Errors wrap one of them so respondWithError can pick
+// the status code and the error code clients see.
+var (
+	errUnauthenticated = errors.New("not authenticated")
+	errInvalidRequest  = errors.New("invalid request")
+	errPrivateProfile  = errors.New("steam profile is private")
+	errNotFound        = errors.New("not found")
+	errRateLimited     = errors.New("rate limited by steam")
+	errUpstreamDown    = errors.New("steam is unavailable")
+	errInvalidAPIKey   = errors.New("steam rejected the api key")
+	errDecode          = errors.New("unexpected steam response")
+	errNotReady        = errors.New("not ready yet")
+)
+
+var errorKinds = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{errUnauthenticated, http.StatusUnauthorized, "unauthenticated"},
+	{errInvalidRequest, http.StatusBadRequest, "invalid_request"},
+	{errPrivateProfile, http.StatusForbidden, "private_profile"},
+	{errNotFound, http.StatusNotFound, "not_found"},
+	{errRateLimited, http.StatusTooManyRequests, "rate_limited"},
+	{errUpstreamDown, http.StatusBadGateway, "upstream_down"},
+	{errInvalidAPIKey, http.StatusBadGateway, "invalid_api_key"},
+	{errDecode, http.StatusBadGateway, "decode_failed"},
+	{errNotReady, http.StatusServiceUnavailable, "not_ready"},
+}
+
+// respondWithError answers with the status of the kind of err and a JSON
+// body carrying the request ID. Server side failures are logged with the
+// cause, which the client doesn't see.
+func respondWithError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	status, code := http.StatusInternalServerError, "internal"
+	for _, kind := range errorKinds {
+		if errors.Is(err, kind.err) {
+			status, code = kind.status, kind.code
+			break
+		}
+	}
+
+	requestID := middleware.GetReqID(r.Context())
+	if status >= http.StatusInternalServerError {
+		log.Printf("Error [%s] %s: %v", requestID, message, err)
+	}
+	if status == http.StatusTooManyRequests {
+		w.Header().Set("Retry-After", "60")
+	}
+
+	respondWithJSON(w, status, ErrorResponse{
+		Error:     code,
+		Message:   message,
+		RequestID: requestID,
+	})
+}
+
+// statusError tells the kind of a failed Steam response. The Web API
+// answers 403 to a bad key, while the community and the stats of private
+// profiles answer 401 or 403 too; the market answers 500 to unknown items.
+func statusError(req *http.Request, status int, body []byte) error {
+	switch {
+	case status == http.StatusTooManyRequests:
+		return errRateLimited
+	case status == http.StatusUnauthorized:
+		return fmt.Errorf("%w: status %d", errPrivateProfile, status)
+	case status == http.StatusForbidden:
+		if req.URL.Hostname() == "api.steampowered.com" && !bytes.Contains(body, []byte("not public")) {
+			return fmt.Errorf("%w: status %d", errInvalidAPIKey, status)
+		}
+		return fmt.Errorf("%w: status %d", errPrivateProfile, status)
+	case status == http.StatusBadRequest || status == http.StatusNotFound:
+		return fmt.Errorf("%w: status %d", errNotFound, status)
+	case status >= http.StatusInternalServerError && successError(body) != nil:
+		return fmt.Errorf("%w: status %d", successError(body), status)
+	}
+	return fmt.Errorf("%w: status %d", errUpstreamDown, status)
+}
+
+// bodyError catches the failures Steam reports with a 200: empty bodies, a
+// null, a success flag that isn't 1 or true, and the empty response of the
+// Web API for private profiles.
+func bodyError(body []byte) error {
+	trimmed := bytes.TrimSpace(body)
+	switch {
+	case len(trimmed) == 0:
+		return fmt.Errorf("%w: empty response", errUpstreamDown)
+	case string(trimmed) == "null":
+		return fmt.Errorf("%w: null response", errNotFound)
+	case trimmed[0] != '{':
+		return nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &fields); err != nil {
+		return fmt.Errorf("%w: %v", errDecode, err)
+	}
+	if err := successError(trimmed); err != nil {
+		return err
+	}
+	if response, ok := fields["response"]; ok && len(fields) == 1 {
+		if string(bytes.Join(bytes.Fields(response), nil)) == "{}" {
+			return fmt.Errorf("%w: empty response", errPrivateProfile)
+		}
+		return successError(response)
+	}
+	return nil
+}
+
+// successError reads the success flag of a Steam object. It is either a
+// bool or an EResult, where 1 is OK.
+func successError(body []byte) error {
+	var result struct {
+		Success json.RawMessage `json:"success"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil || result.Success == nil {
+		return nil
+	}
+
+	switch string(result.Success) {
+	case "true", "1":
+		return nil
+	case "84": // RateLimitExceeded
+		return fmt.Errorf("%w: success 84", errRateLimited)
+	case "2", "16", "20": // Fail, Timeout, ServiceUnavailable
+		return fmt.Errorf("%w: success %s", errUpstreamDown, result.Success)
+	}
+	return fmt.Errorf("%w: success %s", errNotFound, result.Success)
+}
+
+// decodeSteam decodes a Steam response, reporting failures as errDecode.
+func decodeSteam(body []byte, v interface{}) error {
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("%w: %v", errDecode, err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+func TestStatusError(t *testing.T) {
+	cases := []struct {
+		url    string
+		status int
+		body   string
+		want   error
+	}{
+		{"https://api.steampowered.com/IPlayerService/GetOwnedGames/v1/", 429, "", errRateLimited},
+		{"https://api.steampowered.com/IPlayerService/GetOwnedGames/v1/", 403, "<html>Forbidden</html>", errInvalidAPIKey},
+		{"https://api.steampowered.com/ISteamUserStats/GetPlayerAchievements/v1/", 403, `{"playerstats":{"error":"Profile is not public","success":false}}`, errPrivateProfile},
+		{"https://api.steampowered.com/ISteamUser/GetFriendList/v1/", 401, "", errPrivateProfile},
+		{"https://steamcommunity.com/inventory/1/753/6", 403, "null", errPrivateProfile},
+		{"https://api.steampowered.com/ISteamUserStats/GetPlayerAchievements/v1/", 400, `{"playerstats":{"error":"Requested app has no stats","success":false}}`, errNotFound},
+		{"https://steamcommunity.com/market/priceoverview/", 500, `{"success":false}`, errNotFound},
+		{"https://store.steampowered.com/api/appdetails", 502, "Bad Gateway", errUpstreamDown},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest("GET", c.url, nil)
+		if err := statusError(req, c.status, []byte(c.body)); !errors.Is(err, c.want) {
+			t.Errorf("%d from %s: expected %v, got %v", c.status, c.url, c.want, err)
+		}
+	}
+}
+
+func TestBodyError(t *testing.T) {
+	cases := []struct {
+		body string
+		want error
+	}{
+		{"", errUpstreamDown},
+		{"  \n", errUpstreamDown},
+		{"null", errNotFound},
+		{`{"success":false}`, errNotFound},
+		{`{"success":16}`, errUpstreamDown},
+		{`{"success":84}`, errRateLimited},
+		{`{"response":{}}`, errPrivateProfile},
+		{`{"response": { } }`, errPrivateProfile},
+		{`{"response":{"success":42}}`, errNotFound},
+		{`{"success":1,"assets":[]}`, nil},
+		{`{"success":true,"lowest_price":"$1.00"}`, nil},
+		{`{"response":{"game_count":0}}`, nil},
+		{`{"620":{"success":false}}`, nil},
+		{`<html></html>`, nil},
+	}
+
+	for _, c := range cases {
+		err := bodyError([]byte(c.body))
+		if c.want == nil && err != nil {
+			t.Errorf("%q: unexpected error: %v", c.body, err)
+		}
+		if c.want != nil && !errors.Is(err, c.want) {
+			t.Errorf("%q: expected %v, got %v", c.body, c.want, err)
+		}
+	}
+}
+
+func TestRespondWithError(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{errUnauthenticated, http.StatusUnauthorized, "unauthenticated"},
+		{fmt.Errorf("fetching library: %w", errPrivateProfile), http.StatusForbidden, "private_profile"},
+		{fmt.Errorf("decoding: %w", errDecode), http.StatusBadGateway, "decode_failed"},
+		{errRateLimited, http.StatusTooManyRequests, "rate_limited"},
+		{errors.New("disk full"), http.StatusInternalServerError, "internal"},
+	}
+
+	for _, c := range cases {
+		handler := middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			respondWithError(w, r, c.err, "Failed to do it")
+		}))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+		if rec.Code != c.status {
+			t.Errorf("%v: expected status %d, got %d", c.err, c.status, rec.Code)
+		}
+		var body ErrorResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if body.Error != c.code || body.Message != "Failed to do it" || body.RequestID == "" {
+			t.Errorf("%v: unexpected body %+v", c.err, body)
+		}
+	}
+}
+
+func TestFetchResponseBodyRedactsKey(t *testing.T) {
+	h := newReplayHandlers(t)
+
+	// Without a fixture the transport fails, and its error names the URL
+	_, err := h.fetchResponseBody("https://api.steampowered.com/ISteamUser/GetPlayerBans/v1/?key=test-key&steamids=1")
+	if !errors.Is(err, errUpstreamDown) {
+		t.Fatalf("expected the upstream to be down, got %v", err)
+	}
+	if strings.Contains(err.Error(), "test-key") || !strings.Contains(err.Error(), "key=REDACTED") {
+		t.Errorf("expected the key to be redacted, got %v", err)
+	}
+}
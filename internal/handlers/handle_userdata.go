@@ -1,48 +1,40 @@
 package handlers
 
 import (
-	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
+
+	"github.com/masintxi/gamehub/internal/steam"
 )
 
 func (h *SteamHandlers) HandleUserData(w http.ResponseWriter, r *http.Request) {
 	// 1. Get session and validate
 	steamID, err := h.steamAuth.GetSteamID(r)
 	if err != nil {
-		http.Error(w, "Not authenticated", http.StatusUnauthorized)
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
 		return
 	}
 	// 2. Make the request
 	url := fmt.Sprintf("https://api.steampowered.com/ISteamUser/GetPlayerSummaries/v0002/?key=%s&steamids=%s",
 		h.steamAuth.GetAPIKey(), steamID)
 
-	headers := map[string]string{
-		"User-Agent": "Mozilla/5.0",
-	}
-
-	bodyBytes, err := h.getResponseBody(url, headers)
+	bodyBytes, err := h.getResponseBody(url)
 	if err != nil {
-		log.Printf("Error fetching inventory: %v", err)
-		http.Error(w, "Failed to fetch inventory", http.StatusInternalServerError)
+		respondWithError(w, r, err, "Failed to fetch user data")
 		return
 	}
 
 	// 3. Decode JSON into struct
-	var playerResponse PlayerResponse
-	if err := json.Unmarshal(bodyBytes, &playerResponse); err != nil {
-		log.Printf("Error decoding inventory: %v", err)
-		http.Error(w, "Failed to decode inventory", http.StatusInternalServerError)
+	var playerResponse steam.PlayerResponse
+	if err := decodeSteam(bodyBytes, &playerResponse); err != nil {
+		respondWithError(w, r, err, "Failed to decode user data")
 		return
 	}
-	// 5. Send response
-	w.Header().Set("Content-Type", "application/json")
-	if _, err := w.Write(bodyBytes); err != nil {
-		log.Printf("Error writing response: %v", err)
+	if len(playerResponse.Response.Players) == 0 {
+		respondWithError(w, r, errNotFound, "Player not found")
 		return
 	}
 
-	fmt.Println("Response:", string(bodyBytes))
-
+	// 4. Send response
+	respondWithJSON(w, http.StatusOK, steam.NewPlayer(playerResponse.Response.Players[0]))
 }
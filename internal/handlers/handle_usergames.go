@@ -1,77 +1,246 @@
 package handlers
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"sort"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/masintxi/gamehub/internal/steam"
 )
 
-type OwnedGames struct {
-	Response struct {
-		GameCount int            `json:"game_count"`
-		Games     []GameFromList `json:"games"`
-	} `json:"response"`
-}
+const defaultUntouchedMonths = 6
 
 func (h *SteamHandlers) HandleUserGames(w http.ResponseWriter, r *http.Request) {
 	// 1. Get session and validate
 	steamID, err := h.steamAuth.GetSteamID(r)
 	if err != nil {
-		http.Error(w, "Not authenticated", http.StatusUnauthorized)
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	query := r.URL.Query()
+	playedSince, err := parseDateParam(query.Get("played_since"))
+	if err != nil {
+		respondWithError(w, r, errInvalidRequest, "Invalid played_since date, expected YYYY-MM-DD")
+		return
+	}
+	playedBefore, err := parseDateParam(query.Get("played_before"))
+	if err != nil {
+		respondWithError(w, r, errInvalidRequest, "Invalid played_before date, expected YYYY-MM-DD")
 		return
 	}
 
 	// 2. Make the request
-	url := fmt.Sprintf("https://api.steampowered.com/IPlayerService/GetOwnedGames/v1/?key=%s&steamid=%s&include_appinfo=true&include_extended_appinfo=true&format=json",
-		h.steamAuth.GetAPIKey(), steamID)
+	ownedGames, err := h.fetchOwnedGames(steamID)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch owned games")
+		return
+	}
+
+	// 3. Filter by last played date
+	games := make([]LibraryGame, 0, len(ownedGames))
+	for _, game := range ownedGames {
+		libraryGame := newLibraryGame(game)
+		if !playedSince.IsZero() && (libraryGame.LastPlayed == nil || libraryGame.LastPlayed.Before(playedSince)) {
+			continue
+		}
+		if !playedBefore.IsZero() && (libraryGame.LastPlayed == nil || !libraryGame.LastPlayed.Before(playedBefore)) {
+			continue
+		}
+		games = append(games, libraryGame)
+	}
+
+	// 4. Add the live player counts when asked for
+	if query.Get("players") == "true" || query.Get("sort") == "players" {
+		h.addCurrentPlayers(games)
+	}
 
-	headers := map[string]string{
-		"User-Agent": "Mozilla/5.0",
+	// 5. Sort and send response
+	if err := sortLibrary(games, query.Get("sort")); err != nil {
+		respondWithError(w, r, errInvalidRequest, err.Error())
+		return
 	}
 
-	bodyBytes, err := h.getResponseBody(url, headers)
+	respondWithJSON(w, http.StatusOK, games)
+}
+
+func (h *SteamHandlers) HandleRecentGames(w http.ResponseWriter, r *http.Request) {
+	// 1. Get session and validate
+	steamID, err := h.steamAuth.GetSteamID(r)
 	if err != nil {
-		log.Printf("Error fetching inventory: %v", err)
-		http.Error(w, "Failed to fetch inventory", http.StatusInternalServerError)
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
 		return
 	}
 
-	var ownedGamesList OwnedGames
-	err = json.Unmarshal(bodyBytes, &ownedGamesList)
+	// 2. Make the request
+	url := fmt.Sprintf("https://api.steampowered.com/IPlayerService/GetRecentlyPlayedGames/v1/?key=%s&steamid=%s",
+		h.steamAuth.GetAPIKey(), steamID)
+
+	bodyBytes, err := h.getResponseBody(url)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch recent games")
+		return
+	}
+
+	// 3. Decode JSON into struct
+	var recentGames steam.RecentlyPlayedGames
+	if err := decodeSteam(bodyBytes, &recentGames); err != nil {
+		respondWithError(w, r, err, "Failed to decode recent games")
+		return
+	}
+
+	// 4. Add the last played date from the library
+	lastPlayed := map[int]time.Time{}
+	if ownedGames, err := h.fetchOwnedGames(steamID); err == nil {
+		for _, game := range ownedGames {
+			lastPlayed[game.AppID] = game.LastPlayed
+		}
+	} else {
+		log.Printf("Error fetching owned games: %v", err)
+	}
+
+	games := make([]LibraryGame, 0, len(recentGames.Response.Games))
+	for _, raw := range recentGames.Response.Games {
+		game := steam.NewOwnedGame(raw)
+		game.LastPlayed = lastPlayed[game.AppID]
+		games = append(games, newLibraryGame(game))
+	}
+
+	sort.SliceStable(games, func(i, j int) bool {
+		return games[i].Playtime2Weeks > games[j].Playtime2Weeks
+	})
+
+	// 5. Send response
+	respondWithJSON(w, http.StatusOK, games)
+}
+
+func (h *SteamHandlers) HandleUntouchedGames(w http.ResponseWriter, r *http.Request) {
+	// 1. Get session and validate
+	steamID, err := h.steamAuth.GetSteamID(r)
 	if err != nil {
-		fmt.Println("Error unmarshalling response:", err)
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
 		return
 	}
 
-	games := make([]GameFromList, len(ownedGamesList.Response.Games))
-	for i, game := range ownedGamesList.Response.Games {
-		games[i] = GameFromList{
-			AppID:                game.AppID,
-			Name:                 game.Name,
-			PlaytimeForever:      game.PlaytimeForever,
-			PlaytimeDisconnected: game.PlaytimeDisconnected,
-			Playtime2Weeks:       game.Playtime2Weeks,
+	months := defaultUntouchedMonths
+	if m := r.URL.Query().Get("months"); m != "" {
+		months, err = strconv.Atoi(m)
+		if err != nil || months < 1 {
+			respondWithError(w, r, errInvalidRequest, "Invalid months, expected a positive number")
+			return
 		}
 	}
+	includeNever := r.URL.Query().Get("include_never_played") != "false"
 
-	if len(games) == 0 {
-		fmt.Println("No games found")
+	// 2. Make the request
+	ownedGames, err := h.fetchOwnedGames(steamID)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch owned games")
 		return
 	}
 
-	fmt.Printf("%d games found\n", len(games))
+	// 3. Keep the games not played since the cutoff
+	games := untouchedGames(ownedGames, time.Now().AddDate(0, -months, 0), includeNever)
+
+	// 4. Send response
+	respondWithJSON(w, http.StatusOK, games)
+}
+
+// untouchedGames returns the games last played before the cutoff, most
+// recently played first and never played ones last.
+func untouchedGames(ownedGames []steam.OwnedGame, cutoff time.Time, includeNever bool) []LibraryGame {
+	games := []LibraryGame{}
+	for _, game := range ownedGames {
+		libraryGame := newLibraryGame(game)
+		if libraryGame.LastPlayed == nil {
+			if includeNever {
+				games = append(games, libraryGame)
+			}
+			continue
+		}
+		if libraryGame.LastPlayed.Before(cutoff) {
+			games = append(games, libraryGame)
+		}
+	}
+
+	sortLibrary(games, "last_played")
+	return games
+}
+
+func (h *SteamHandlers) fetchOwnedGames(steamID string) ([]steam.OwnedGame, error) {
+	url := fmt.Sprintf("https://api.steampowered.com/IPlayerService/GetOwnedGames/v1/?key=%s&steamid=%s&include_appinfo=true&include_extended_appinfo=true&format=json",
+		h.steamAuth.GetAPIKey(), steamID)
+
+	bodyBytes, err := h.getResponseBody(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var ownedGamesList steam.OwnedGamesResponse
+	if err := decodeSteam(bodyBytes, &ownedGamesList); err != nil {
+		return nil, fmt.Errorf("decoding owned games: %w", err)
+	}
+
+	return steam.NewOwnedGames(ownedGamesList), nil
+}
+
+func newLibraryGame(game steam.OwnedGame) LibraryGame {
+	libraryGame := LibraryGame{
+		AppID:           game.AppID,
+		Name:            game.Name,
+		PlaytimeForever: game.Playtime,
+		Playtime2Weeks:  game.Playtime2Weeks,
+		ImgIconURL:      game.IconHash,
+	}
+	if !game.LastPlayed.IsZero() {
+		lastPlayed := game.LastPlayed
+		libraryGame.LastPlayed = &lastPlayed
+	}
+	return libraryGame
+}
+
+// sortLibrary orders the games by the given column. Games never played sort
+// last when ordering by the last played date.
+func sortLibrary(games []LibraryGame, by string) error {
+	var less func(a, b LibraryGame) bool
+	switch by {
+	case "", "playtime":
+		less = func(a, b LibraryGame) bool { return a.PlaytimeForever > b.PlaytimeForever }
+	case "recent":
+		less = func(a, b LibraryGame) bool { return a.Playtime2Weeks > b.Playtime2Weeks }
+	case "name":
+		less = func(a, b LibraryGame) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) }
+	case "players":
+		less = func(a, b LibraryGame) bool {
+			if a.CurrentPlayers == nil || b.CurrentPlayers == nil {
+				return b.CurrentPlayers == nil && a.CurrentPlayers != nil
+			}
+			return *a.CurrentPlayers > *b.CurrentPlayers
+		}
+	case "last_played":
+		less = func(a, b LibraryGame) bool {
+			if a.LastPlayed == nil || b.LastPlayed == nil {
+				return b.LastPlayed == nil && a.LastPlayed != nil
+			}
+			return a.LastPlayed.After(*b.LastPlayed)
+		}
+	default:
+		return fmt.Errorf("invalid sort %q, expected playtime, recent, name, players or last_played", by)
+	}
 
 	sort.SliceStable(games, func(i, j int) bool {
-		return games[i].PlaytimeForever > games[j].PlaytimeForever
+		return less(games[i], games[j])
 	})
+	return nil
+}
 
-	for i := 0; i < 10 && i < len(games); i++ {
-		game := h.GetGameData(strconv.FormatInt(int64(games[i].AppID), 10))
-		fmt.Printf("- %s (AppID: %d, Tiempo jugado: %d minutos)\n",
-			games[i].Name, games[i].AppID, games[i].PlaytimeForever)
-		fmt.Printf("  * %s\n", game.Data.ShortDescription)
+func parseDateParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
 	}
+	return time.Parse("2006-01-02", value)
 }
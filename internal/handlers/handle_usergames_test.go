@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+func TestSortLibraryLastPlayed(t *testing.T) {
+	at := func(year int) *time.Time {
+		played := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+		return &played
+	}
+	games := []LibraryGame{
+		{Name: "never A"},
+		{Name: "2019", LastPlayed: at(2019)},
+		{Name: "never B"},
+		{Name: "2024", LastPlayed: at(2024)},
+		{Name: "2021", LastPlayed: at(2021)},
+	}
+
+	if err := sortLibrary(games, "last_played"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Never played games go last and keep their order
+	want := []string{"2024", "2021", "2019", "never A", "never B"}
+	for i, name := range want {
+		if games[i].Name != name {
+			t.Errorf("%d: expected %s, got %s", i, name, games[i].Name)
+		}
+	}
+}
+
+func TestUntouchedGames(t *testing.T) {
+	cutoff := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	owned := []steam.OwnedGame{
+		{AppID: 1, Name: "before", LastPlayed: cutoff.AddDate(0, -2, 0)},
+		{AppID: 2, Name: "never"},
+		{AppID: 3, Name: "after", LastPlayed: cutoff.AddDate(0, 0, 1)},
+		{AppID: 4, Name: "long before", LastPlayed: cutoff.AddDate(-3, 0, 0)},
+		{AppID: 5, Name: "at cutoff", LastPlayed: cutoff},
+	}
+
+	cases := []struct {
+		name         string
+		includeNever bool
+		want         []string
+	}{
+		{"with never played", true, []string{"before", "long before", "never"}},
+		{"without never played", false, []string{"before", "long before"}},
+	}
+	for _, c := range cases {
+		games := untouchedGames(owned, cutoff, c.includeNever)
+		if len(games) != len(c.want) {
+			t.Errorf("%s: expected %d games, got %+v", c.name, len(c.want), games)
+			continue
+		}
+		for i, name := range c.want {
+			if games[i].Name != name {
+				t.Errorf("%s: %d: expected %s, got %s", c.name, i, name, games[i].Name)
+			}
+		}
+	}
+}
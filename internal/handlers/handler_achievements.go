@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+func (h *SteamHandlers) HandleGameAchievements(w http.ResponseWriter, r *http.Request) {
+	// 1. Get session and validate
+	steamID, err := h.steamAuth.GetSteamID(r)
+	if err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	appID, err := h.resolveAppID(chi.URLParam(r, "appid"))
+	if err != nil {
+		respondWithError(w, r, fmt.Errorf("%w: %v", errNotFound, err), err.Error())
+		return
+	}
+
+	// 2. Join player achievements, schema and global rarity
+	progress, err := h.fetchAchievementProgress(steamID, appID, LocaleFromContext(r.Context()))
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch achievements")
+		return
+	}
+
+	// 3. Send response
+	respondWithJSON(w, http.StatusOK, progress)
+}
+
+func (h *SteamHandlers) HandleAchievementSummary(w http.ResponseWriter, r *http.Request) {
+	// 1. Get session and validate
+	steamID, err := h.steamAuth.GetSteamID(r)
+	if err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	// 2. Get the library
+	ownedGames, err := h.fetchOwnedGames(steamID)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch owned games")
+		return
+	}
+
+	// 3. Count the achievements of every played game with stats
+	loc := LocaleFromContext(r.Context())
+	summary := AchievementSummary{
+		Games: []GameAchievementTotal{},
+	}
+	for _, game := range ownedGames {
+		if !game.HasStats || game.Playtime == 0 {
+			continue
+		}
+
+		playerStats, err := h.fetchPlayerAchievements(steamID, game.AppID, loc)
+		if errors.Is(err, errRateLimited) {
+			respondWithError(w, r, err, "Steam rate limit reached, retry later to resume")
+			return
+		}
+		if err != nil {
+			// Games without achievements answer with an error status
+			continue
+		}
+
+		total := len(playerStats.Playerstats.Achievements)
+		if total == 0 {
+			continue
+		}
+		unlocked := 0
+		for _, a := range playerStats.Playerstats.Achievements {
+			if a.Achieved == 1 {
+				unlocked++
+			}
+		}
+
+		gameTotal := GameAchievementTotal{
+			AppID:             game.AppID,
+			Name:              game.Name,
+			Total:             total,
+			Unlocked:          unlocked,
+			CompletionPercent: percent(unlocked, total),
+		}
+		summary.Games = append(summary.Games, gameTotal)
+		summary.Total += total
+		summary.Unlocked += unlocked
+		summary.AverageGameCompletion += gameTotal.CompletionPercent
+		if unlocked == total {
+			summary.PerfectGames++
+		}
+	}
+
+	summary.GamesWithAchievements = len(summary.Games)
+	summary.CompletionPercent = percent(summary.Unlocked, summary.Total)
+	if summary.GamesWithAchievements > 0 {
+		summary.AverageGameCompletion /= float64(summary.GamesWithAchievements)
+	}
+
+	sort.SliceStable(summary.Games, func(i, j int) bool {
+		return summary.Games[i].CompletionPercent > summary.Games[j].CompletionPercent
+	})
+
+	// 4. Send response
+	respondWithJSON(w, http.StatusOK, summary)
+}
+
+func (h *SteamHandlers) fetchAchievementProgress(steamID string, appID int, loc Locale) (AchievementProgress, error) {
+	gameID := strconv.Itoa(appID)
+
+	playerStats, err := h.fetchPlayerAchievements(steamID, appID, loc)
+	if err != nil {
+		return AchievementProgress{}, err
+	}
+
+	schema, err := h.GetGameSchema(gameID, loc)
+	if err != nil {
+		return AchievementProgress{}, err
+	}
+
+	globalPercents, err := h.fetchGlobalAchievementPercentages(appID)
+	if err != nil {
+		// Rarity is nice to have, the progress is still useful without it
+		log.Printf("Error fetching global achievement percentages: %v", err)
+	}
+
+	unlocked := make(map[string]int64, len(playerStats.Playerstats.Achievements))
+	for _, a := range playerStats.Playerstats.Achievements {
+		if a.Achieved == 1 {
+			unlocked[a.Apiname] = a.Unlocktime
+		}
+	}
+
+	progress := AchievementProgress{
+		AppID:        appID,
+		GameName:     schema.Game.GameName,
+		Achievements: []AchievementStatus{},
+	}
+	for _, a := range schema.Game.AvailableGameStats.Achievements {
+		status := AchievementStatus{
+			APIName:       a.Name,
+			Name:          a.DisplayName,
+			Description:   a.Description,
+			Icon:          a.Icongray,
+			Hidden:        a.Hidden == 1,
+			GlobalPercent: globalPercents[a.Name],
+		}
+		if unlockTime, ok := unlocked[a.Name]; ok {
+			status.Achieved = true
+			status.Icon = a.Icon
+			if unlockTime > 0 {
+				t := time.Unix(unlockTime, 0).UTC()
+				status.UnlockTime = &t
+			}
+			progress.Unlocked++
+		}
+		progress.Achievements = append(progress.Achievements, status)
+	}
+
+	progress.Total = len(progress.Achievements)
+	progress.CompletionPercent = percent(progress.Unlocked, progress.Total)
+
+	return progress, nil
+}
+
+func (h *SteamHandlers) fetchPlayerAchievements(steamID string, appID int, loc Locale) (steam.PlayerAchievementsResponse, error) {
+	url := fmt.Sprintf("https://api.steampowered.com/ISteamUserStats/GetPlayerAchievements/v1/?key=%s&steamid=%s&appid=%d&l=%s",
+		h.steamAuth.GetAPIKey(), steamID, appID, loc.Language)
+
+	bodyBytes, err := h.getResponseBody(url)
+	if err != nil {
+		return steam.PlayerAchievementsResponse{}, err
+	}
+
+	var playerStats steam.PlayerAchievementsResponse
+	if err := decodeSteam(bodyBytes, &playerStats); err != nil {
+		return steam.PlayerAchievementsResponse{}, fmt.Errorf("decoding player achievements: %w", err)
+	}
+	if !playerStats.Playerstats.Success {
+		return steam.PlayerAchievementsResponse{}, fmt.Errorf("%w: player achievements: %s", errNotFound, playerStats.Playerstats.Error)
+	}
+
+	return playerStats, nil
+}
+
+func (h *SteamHandlers) fetchGlobalAchievementPercentages(appID int) (map[string]float64, error) {
+	url := fmt.Sprintf("https://api.steampowered.com/ISteamUserStats/GetGlobalAchievementPercentagesForApp/v2/?gameid=%d", appID)
+
+	bodyBytes, err := h.getResponseBody(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var globalResponse steam.GlobalAchievementPercentagesResponse
+	if err := decodeSteam(bodyBytes, &globalResponse); err != nil {
+		return nil, fmt.Errorf("decoding global achievement percentages: %w", err)
+	}
+
+	percents := make(map[string]float64, len(globalResponse.Achievementpercentages.Achievements))
+	for _, a := range globalResponse.Achievementpercentages.Achievements {
+		p, err := a.Percent.Float64()
+		if err != nil {
+			continue
+		}
+		percents[a.Name] = p
+	}
+
+	return percents, nil
+}
+
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(total)
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFetchAchievementProgress(t *testing.T) {
+	h := newReplayHandlers(t)
+
+	progress, err := h.fetchAchievementProgress(testSteamID, 620, withLocaleDefaults(Locale{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if progress.GameName != "Portal 2" || progress.Total != 4 || progress.Unlocked != 2 || progress.CompletionPercent != 50 {
+		t.Errorf("unexpected progress %+v", progress)
+	}
+	if len(progress.Achievements) != 4 {
+		t.Fatalf("expected 4 achievements, got %d", len(progress.Achievements))
+	}
+
+	rideUnlock := time.Unix(1303171200, 0).UTC()
+	cases := []struct {
+		apiName    string
+		name       string
+		achieved   bool
+		icon       string // Colored when unlocked, gray otherwise
+		unlockTime *time.Time
+		hidden     bool
+		global     float64
+	}{
+		{"ACH.SURVIVE_CONTAINER_RIDE", "Wake Up Call", true, "ride.jpg", &rideUnlock, false, 87.5},
+		{"ACH.WAKE_UP", "You Monster", false, "wake_gray.jpg", nil, false, 54.25},
+		// Unlocked before Steam recorded unlock times
+		{"ACH.LASER", "Undiscouraged", true, "laser.jpg", nil, false, 70.1},
+		// No global rarity for it
+		{"ACH.NEW_BLOOD", "Lunacy", false, "blood_gray.jpg", nil, true, 0},
+	}
+	for i, c := range cases {
+		got := progress.Achievements[i]
+		if got.APIName != c.apiName || got.Name != c.name {
+			t.Errorf("%d: expected %s (%s), got %s (%s)", i, c.apiName, c.name, got.APIName, got.Name)
+			continue
+		}
+		if got.Achieved != c.achieved || got.Hidden != c.hidden || got.GlobalPercent != c.global {
+			t.Errorf("%s: unexpected status %+v", c.apiName, got)
+		}
+		if !strings.HasSuffix(got.Icon, "/"+c.icon) {
+			t.Errorf("%s: expected icon %s, got %s", c.apiName, c.icon, got.Icon)
+		}
+		switch {
+		case c.unlockTime == nil && got.UnlockTime != nil:
+			t.Errorf("%s: expected no unlock time, got %v", c.apiName, got.UnlockTime)
+		case c.unlockTime != nil && (got.UnlockTime == nil || !got.UnlockTime.Equal(*c.unlockTime)):
+			t.Errorf("%s: expected unlock time %v, got %v", c.apiName, c.unlockTime, got.UnlockTime)
+		}
+	}
+}
+
+func TestReplayAchievementSummary(t *testing.T) {
+	h := newReplayHandlers(t)
+	handler := h.LocaleMiddleware(http.HandlerFunc(h.HandleAchievementSummary))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, authenticatedRequest(t, h, "/achievements"))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var summary AchievementSummary
+	if err := json.Unmarshal(rec.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Portal has stats but was never played, so only two games count
+	cases := []struct {
+		name      string
+		got, want float64
+	}{
+		{"games with achievements", float64(summary.GamesWithAchievements), 2},
+		{"perfect games", float64(summary.PerfectGames), 1},
+		{"total", float64(summary.Total), 6},
+		{"unlocked", float64(summary.Unlocked), 4},
+		{"completion percent", summary.CompletionPercent, 400.0 / 6},
+		{"average game completion", summary.AverageGameCompletion, 75},
+	}
+	for _, c := range cases {
+		if math.Abs(c.got-c.want) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", c.name, c.want, c.got)
+		}
+	}
+
+	if len(summary.Games) != 2 || summary.Games[0].Name != "Half-Life 2" || summary.Games[1].CompletionPercent != 50 {
+		t.Errorf("expected the games by completion, got %+v", summary.Games)
+	}
+}
@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+// The "Pillar of Community" badge is earned through the community quests
+const communityBadgeID = 2
+
+// The badge page is always read in English, the only language the pattern knows
+var cardDropsRegExp = regexp.MustCompile(`(\d+|No) card drops? remaining`)
+
+func (h *SteamHandlers) HandleBadges(w http.ResponseWriter, r *http.Request) {
+	// 1. Get session and validate
+	steamID, err := h.steamAuth.GetSteamID(r)
+	if err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	loc := LocaleFromContext(r.Context())
+
+	// 2. Level, XP and badges
+	level, err := h.fetchSteamLevel(steamID)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch steam level")
+		return
+	}
+
+	badges, err := h.fetchBadges(steamID)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch badges")
+		return
+	}
+
+	overview := BadgeOverview{
+		Level:                level,
+		XP:                   badges.Response.PlayerXP,
+		XPNeededToLevelUp:    badges.Response.PlayerXPNeededToLevelUp,
+		XPNeededCurrentLevel: badges.Response.PlayerXPNeededCurrentLevel,
+		GameBadges:           []GameBadgeProgress{},
+	}
+
+	overview.CommunityBadge, err = h.fetchCommunityBadgeProgress(steamID)
+	if err != nil {
+		log.Printf("Error fetching community badge progress: %v", err)
+	}
+
+	// 3. Trading cards held, from the Steam Community inventory
+	items, _, err := h.fetchInventory(steamID, defaultInventoryAppID, defaultInventoryContextID, loc)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch inventory")
+		return
+	}
+
+	progress := map[int]*GameBadgeProgress{}
+	gameBadge := func(appID int) *GameBadgeProgress {
+		if _, ok := progress[appID]; !ok {
+			progress[appID] = &GameBadgeProgress{AppID: appID}
+		}
+		return progress[appID]
+	}
+
+	for _, badge := range badges.Response.Badges {
+		if badge.AppID == 0 || badge.BorderColor != 0 {
+			continue
+		}
+		gameBadge(badge.AppID).BadgeLevel = badge.Level
+	}
+
+	held := map[int]map[string]int{}
+	for _, item := range items {
+		appID, ok := cardAppID(item)
+		if !ok {
+			continue
+		}
+		badge := gameBadge(appID)
+		badge.Game = item.Game
+		if held[appID] == nil {
+			held[appID] = map[string]int{}
+		}
+		held[appID][item.MarketHashName]++
+	}
+
+	// 4. Compare with the full card set of every game
+	for appID, badge := range progress {
+		cardSet, err := h.fetchCardSet(appID, loc)
+		if errors.Is(err, errRateLimited) {
+			respondWithError(w, r, err, "Steam rate limit reached, retry later to resume")
+			return
+		}
+		if err != nil {
+			log.Printf("Error fetching card set of %d: %v", appID, err)
+		}
+
+		badge.TotalCards = len(cardSet)
+		badge.CardsHeld = []CardCount{}
+		badge.CardsMissing = []string{}
+		for _, card := range cardSet {
+			if count := held[appID][card.HashName]; count > 0 {
+				badge.CardsHeld = append(badge.CardsHeld, CardCount{Name: card.Name, Count: count})
+			} else {
+				badge.CardsMissing = append(badge.CardsMissing, card.Name)
+			}
+		}
+		if len(cardSet) == 0 {
+			for hashName, count := range held[appID] {
+				badge.CardsHeld = append(badge.CardsHeld, CardCount{Name: hashName, Count: count})
+			}
+		}
+
+		badge.CardDropsRemaining = h.fetchCardDropsRemaining(steamID, appID)
+		overview.GameBadges = append(overview.GameBadges, *badge)
+	}
+
+	sort.SliceStable(overview.GameBadges, func(i, j int) bool {
+		return len(overview.GameBadges[i].CardsMissing) < len(overview.GameBadges[j].CardsMissing)
+	})
+
+	// 5. Send response
+	respondWithJSON(w, http.StatusOK, overview)
+}
+
+func (h *SteamHandlers) fetchSteamLevel(steamID string) (int, error) {
+	url := fmt.Sprintf("https://api.steampowered.com/IPlayerService/GetSteamLevel/v1/?key=%s&steamid=%s",
+		h.steamAuth.GetAPIKey(), steamID)
+
+	bodyBytes, err := h.getResponseBody(url)
+	if err != nil {
+		return 0, err
+	}
+
+	var levelResponse steam.SteamLevelResponse
+	if err := decodeSteam(bodyBytes, &levelResponse); err != nil {
+		return 0, fmt.Errorf("decoding steam level: %w", err)
+	}
+
+	return levelResponse.Response.PlayerLevel, nil
+}
+
+func (h *SteamHandlers) fetchBadges(steamID string) (steam.BadgesResponse, error) {
+	url := fmt.Sprintf("https://api.steampowered.com/IPlayerService/GetBadges/v1/?key=%s&steamid=%s",
+		h.steamAuth.GetAPIKey(), steamID)
+
+	bodyBytes, err := h.getResponseBody(url)
+	if err != nil {
+		return steam.BadgesResponse{}, err
+	}
+
+	var badges steam.BadgesResponse
+	if err := decodeSteam(bodyBytes, &badges); err != nil {
+		return steam.BadgesResponse{}, fmt.Errorf("decoding badges: %w", err)
+	}
+
+	return badges, nil
+}
+
+func (h *SteamHandlers) fetchCommunityBadgeProgress(steamID string) (CommunityBadgeProgress, error) {
+	url := fmt.Sprintf("https://api.steampowered.com/IPlayerService/GetCommunityBadgeProgress/v1/?key=%s&steamid=%s&badgeid=%d",
+		h.steamAuth.GetAPIKey(), steamID, communityBadgeID)
+
+	bodyBytes, err := h.getResponseBody(url)
+	if err != nil {
+		return CommunityBadgeProgress{}, err
+	}
+
+	var progressResponse steam.CommunityBadgeProgressResponse
+	if err := decodeSteam(bodyBytes, &progressResponse); err != nil {
+		return CommunityBadgeProgress{}, fmt.Errorf("decoding community badge progress: %w", err)
+	}
+
+	progress := CommunityBadgeProgress{
+		Total: len(progressResponse.Response.Quests),
+	}
+	for _, quest := range progressResponse.Response.Quests {
+		if quest.Completed {
+			progress.Completed++
+		}
+	}
+
+	return progress, nil
+}
+
+// fetchCardSet lists the normal (non-foil) trading cards of a game through
+// the market search, the only public place that knows the whole set.
+func (h *SteamHandlers) fetchCardSet(appID int, loc Locale) ([]steam.MarketSearchResult, error) {
+	q := url.Values{}
+	q.Set("norender", "1")
+	q.Set("appid", "753")
+	q.Set("count", "100")
+	q.Set("l", loc.Language)
+	q.Set("category_753_Game[]", fmt.Sprintf("tag_app_%d", appID))
+	q.Set("category_753_cardborder[]", "tag_cardborder_0")
+	q.Set("category_753_item_class[]", "tag_item_class_2")
+	searchURL := "https://steamcommunity.com/market/search/render/?" + q.Encode()
+
+	bodyBytes, err := h.getResponseBody(searchURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var search steam.MarketSearchResponse
+	if err := decodeSteam(bodyBytes, &search); err != nil {
+		return nil, fmt.Errorf("decoding market search: %w", err)
+	}
+	if !search.Success {
+		return nil, fmt.Errorf("%w: market search not successful", errNotFound)
+	}
+
+	return search.Results, nil
+}
+
+// fetchCardDropsRemaining reads the drops left from the badge page. Steam only
+// shows them to the owner, so it needs the market session cookies of that
+// account and returns nil when they are missing, belong to someone else or
+// the page can't be read.
+func (h *SteamHandlers) fetchCardDropsRemaining(steamID string, appID int) *int {
+	cookies := h.steamAuth.GetMarketCookies()
+	if cookies["sessionid"] == "" || h.steamAuth.MarketSteamID() != steamID {
+		return nil
+	}
+
+	pageURL := fmt.Sprintf("https://steamcommunity.com/profiles/%s/gamecards/%d/?l=english", steamID, appID)
+
+	// Drops go down while playing, so the page skips the cache
+	bodyBytes, err := h.fetchResponseBody(pageURL)
+	if err != nil {
+		log.Printf("Error fetching badge page of %d: %v", appID, err)
+		return nil
+	}
+
+	match := cardDropsRegExp.FindStringSubmatch(string(bodyBytes))
+	if match == nil {
+		return nil
+	}
+
+	drops := 0
+	if match[1] != "No" {
+		drops, _ = strconv.Atoi(match[1])
+	}
+	return &drops
+}
+
+// cardAppID returns the game of a normal trading card. Steam Community items
+// use "<appid>-<name>" as market hash name. Cards are matched by the internal
+// tag names, since the localized ones follow the language of the inventory.
+func cardAppID(item InventoryItem) (int, bool) {
+	if item.InternalTags["item_class"] != "item_class_2" || item.InternalTags["cardborder"] == "cardborder_1" {
+		return 0, false
+	}
+
+	prefix, _, ok := strings.Cut(item.MarketHashName, "-")
+	if !ok {
+		return 0, false
+	}
+
+	appID, err := strconv.Atoi(prefix)
+	if err != nil {
+		return 0, false
+	}
+	return appID, true
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/masintxi/gamehub/internal/catalog"
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 100
+
+	// Names resolved to an appid must match at least this well
+	minResolveScore = 0.6
+)
+
+func (h *SteamHandlers) HandleGameSearch(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		respondWithError(w, r, errInvalidRequest, "Query parameter q is required")
+		return
+	}
+
+	limit := intParam(r.URL.Query().Get("limit"), defaultSearchLimit)
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	if h.client.Catalog.Len() == 0 {
+		respondWithError(w, r, errNotReady, "App catalog not imported yet")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, h.client.Catalog.Search(query, limit))
+}
+
+func (h *SteamHandlers) HandleGameData(w http.ResponseWriter, r *http.Request) {
+	appID, err := h.resolveAppID(chi.URLParam(r, "appid"))
+	if err != nil {
+		respondWithError(w, r, fmt.Errorf("%w: %v", errNotFound, err), err.Error())
+		return
+	}
+
+	gameData := h.GetGameData(strconv.Itoa(appID), LocaleFromContext(r.Context()))
+	if !gameData.Success {
+		if gameData.Data.Name == "" {
+			respondWithError(w, r, errNotFound, "Game not found")
+			return
+		}
+		// The store is down, the catalog only knows the name
+		respondWithJSON(w, http.StatusOK, steam.Game{AppID: gameData.Data.SteamAppid, Name: gameData.Data.Name})
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, steam.NewGame(gameData))
+}
+
+// resolveAppID accepts either a numeric appid or a game name, which is looked
+// up in the local catalog.
+func (h *SteamHandlers) resolveAppID(param string) (int, error) {
+	if appID, err := strconv.Atoi(param); err == nil {
+		return appID, nil
+	}
+
+	name, err := url.PathUnescape(param)
+	if err != nil {
+		name = param
+	}
+
+	matches := h.client.Catalog.Search(name, 1)
+	if len(matches) == 0 || matches[0].Score < minResolveScore {
+		return 0, fmt.Errorf("no game found for %q", name)
+	}
+
+	return matches[0].AppID, nil
+}
+
+// refreshCatalog imports the full app list. It is too big for the cache, so
+// it is always fetched fresh and saved by the catalog itself.
+func (h *SteamHandlers) refreshCatalog() {
+	url := "https://api.steampowered.com/ISteamApps/GetAppList/v2/"
+
+	bodyBytes, err := h.fetchResponseBody(url)
+	if err != nil {
+		log.Printf("Error fetching app list: %v", err)
+		return
+	}
+
+	var appList steam.AppListResponse
+	if err := decodeSteam(bodyBytes, &appList); err != nil {
+		log.Printf("Error decoding app list: %v", err)
+		return
+	}
+
+	apps := make([]catalog.App, 0, len(appList.Applist.Apps))
+	for _, app := range appList.Applist.Apps {
+		if strings.TrimSpace(app.Name) == "" {
+			continue
+		}
+		apps = append(apps, catalog.App{
+			AppID: app.AppID,
+			Name:  app.Name,
+		})
+	}
+
+	if len(apps) == 0 {
+		log.Printf("Empty app list, keeping the current catalog")
+		return
+	}
+
+	if err := h.client.Catalog.Replace(apps); err != nil {
+		log.Printf("Error saving catalog: %v", err)
+		return
+	}
+	log.Printf("App catalog refreshed: %d apps", len(apps))
+}
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+const (
+	// GetPlayerSummaries and GetPlayerBans accept up to 100 SteamIDs per call
+	steamIDBatchSize = 100
+
+	defaultTogetherLimit = 25
+	maxTogetherLimit     = 50
+	// appdetails only returns categories one app at a time, so only the
+	// most played shared games are looked up
+	maxTogetherLookups = 75
+)
+
+// Store categories that mean the game can be played with other people
+var multiplayerCategories = map[int]bool{
+	1:  true, // Multi-player
+	9:  true, // Co-op
+	20: true, // MMO
+	24: true, // Shared/Split Screen
+	27: true, // Cross-Platform Multiplayer
+	36: true, // Online PvP
+	37: true, // Shared/Split Screen PvP
+	38: true, // Online Co-op
+	39: true, // Shared/Split Screen Co-op
+	47: true, // LAN PvP
+	48: true, // LAN Co-op
+	49: true, // PvP
+}
+
+func (h *SteamHandlers) HandleFriends(w http.ResponseWriter, r *http.Request) {
+	// 1. Get session and validate
+	steamID, err := h.steamAuth.GetSteamID(r)
+	if err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	// 2. Make the request
+	url := fmt.Sprintf("https://api.steampowered.com/ISteamUser/GetFriendList/v1/?key=%s&steamid=%s&relationship=friend",
+		h.steamAuth.GetAPIKey(), steamID)
+
+	bodyBytes, err := h.getResponseBody(url)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch friend list")
+		return
+	}
+
+	// 3. Decode JSON into struct
+	var friendList steam.FriendListResponse
+	if err := decodeSteam(bodyBytes, &friendList); err != nil {
+		respondWithError(w, r, err, "Failed to decode friend list")
+		return
+	}
+
+	// 4. Add the summary of every friend
+	friendIDs := make([]string, len(friendList.Friendslist.Friends))
+	for i, f := range friendList.Friendslist.Friends {
+		friendIDs[i] = f.Steamid
+	}
+
+	summaries, err := h.fetchPlayerSummaries(friendIDs)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch friend summaries")
+		return
+	}
+
+	friends := make([]Friend, 0, len(friendList.Friendslist.Friends))
+	for _, f := range friendList.Friendslist.Friends {
+		friend := Friend{
+			SteamID:     f.Steamid,
+			FriendSince: time.Unix(f.FriendSince, 0).UTC(),
+		}
+		if summary, ok := summaries[f.Steamid]; ok {
+			friend.PersonaName = summary.Name
+			friend.PersonaState = summary.State
+			friend.ProfileURL = summary.ProfileURL
+			friend.Avatar = summary.Avatar
+			friend.Playing = summary.Playing
+		}
+		friends = append(friends, friend)
+	}
+
+	sort.SliceStable(friends, func(i, j int) bool {
+		return strings.ToLower(friends[i].PersonaName) < strings.ToLower(friends[j].PersonaName)
+	})
+
+	// 5. Send response
+	respondWithJSON(w, http.StatusOK, friends)
+}
+
+func (h *SteamHandlers) HandlePlayTogether(w http.ResponseWriter, r *http.Request) {
+	// 1. Get session and validate
+	steamID, err := h.steamAuth.GetSteamID(r)
+	if err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	steamIDs := []string{steamID}
+	for _, id := range strings.Split(r.URL.Query().Get("steamids"), ",") {
+		id = strings.TrimSpace(id)
+		if id == "" || id == steamID {
+			continue
+		}
+		if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+			respondWithError(w, r, errInvalidRequest, fmt.Sprintf("Invalid SteamID: %q", id))
+			return
+		}
+		steamIDs = append(steamIDs, id)
+	}
+	if len(steamIDs) < 2 {
+		respondWithError(w, r, errInvalidRequest, "At least one other SteamID is required")
+		return
+	}
+
+	limit := intParam(r.URL.Query().Get("limit"), defaultTogetherLimit)
+	if limit > maxTogetherLimit {
+		limit = maxTogetherLimit
+	}
+
+	// 2. Intersect the libraries
+	shared, err := h.intersectLibraries(steamIDs)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch libraries")
+		return
+	}
+
+	// 3. Keep the multiplayer games, most played first
+	sort.SliceStable(shared, func(i, j int) bool {
+		return shared[i].CombinedPlaytime > shared[j].CombinedPlaytime
+	})
+
+	if len(shared) > maxTogetherLookups {
+		shared = shared[:maxTogetherLookups]
+	}
+
+	loc := LocaleFromContext(r.Context())
+	games := []SharedGame{}
+	for _, game := range shared {
+		if len(games) >= limit {
+			break
+		}
+
+		gameData, err := h.fetchGameData(strconv.Itoa(game.AppID), loc)
+		if err != nil {
+			respondWithError(w, r, err, "Failed to fetch game details")
+			return
+		}
+		if !gameData.Success {
+			continue
+		}
+
+		game.Categories = multiplayerCategoryNames(gameData.Data.Categories)
+		if len(game.Categories) > 0 {
+			games = append(games, game)
+		}
+	}
+
+	// 4. Send response
+	respondWithJSON(w, http.StatusOK, games)
+}
+
+// multiplayerCategoryNames returns the names of the store categories that
+// mean the game can be played together.
+func multiplayerCategoryNames(categories []steam.Category) []string {
+	var names []string
+	for _, category := range categories {
+		if multiplayerCategories[category.ID] {
+			names = append(names, category.Description)
+		}
+	}
+	return names
+}
+
+// intersectLibraries returns the games owned by every given user with the
+// playtime each of them has in it.
+func (h *SteamHandlers) intersectLibraries(steamIDs []string) ([]SharedGame, error) {
+	var shared map[int]*SharedGame
+	for _, id := range steamIDs {
+		ownedGames, err := h.fetchOwnedGames(id)
+		if err != nil {
+			return nil, fmt.Errorf("fetching library of %s: %w", id, err)
+		}
+		if len(ownedGames) == 0 {
+			return nil, fmt.Errorf("%w: library of %s is empty or not visible", errPrivateProfile, id)
+		}
+
+		owned := make(map[int]steam.OwnedGame, len(ownedGames))
+		for _, game := range ownedGames {
+			owned[game.AppID] = game
+		}
+
+		if shared == nil {
+			shared = make(map[int]*SharedGame, len(owned))
+			for _, game := range owned {
+				shared[game.AppID] = &SharedGame{
+					AppID:     game.AppID,
+					Name:      game.Name,
+					Playtimes: map[string]int{},
+				}
+			}
+		}
+
+		for appID, game := range shared {
+			ownedGame, ok := owned[appID]
+			if !ok {
+				delete(shared, appID)
+				continue
+			}
+			game.Playtimes[id] = ownedGame.Playtime
+			game.CombinedPlaytime += ownedGame.Playtime
+		}
+	}
+
+	games := make([]SharedGame, 0, len(shared))
+	for _, game := range shared {
+		games = append(games, *game)
+	}
+
+	return games, nil
+}
+
+func (h *SteamHandlers) fetchPlayerSummaries(steamIDs []string) (map[string]steam.Player, error) {
+	summaries := make(map[string]steam.Player, len(steamIDs))
+	for start := 0; start < len(steamIDs); start += steamIDBatchSize {
+		end := min(start+steamIDBatchSize, len(steamIDs))
+
+		url := fmt.Sprintf("https://api.steampowered.com/ISteamUser/GetPlayerSummaries/v0002/?key=%s&steamids=%s",
+			h.steamAuth.GetAPIKey(), strings.Join(steamIDs[start:end], ","))
+
+		bodyBytes, err := h.getResponseBody(url)
+		if err != nil {
+			return nil, err
+		}
+
+		var playerResponse steam.PlayerResponse
+		if err := decodeSteam(bodyBytes, &playerResponse); err != nil {
+			return nil, fmt.Errorf("decoding player summaries: %w", err)
+		}
+
+		for _, player := range playerResponse.Response.Players {
+			summaries[player.Steamid] = steam.NewPlayer(player)
+		}
+	}
+
+	return summaries, nil
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+const (
+	testFriendID        = "76561197960287931"
+	testPrivateFriendID = "76561197960287932"
+)
+
+func TestIntersectLibraries(t *testing.T) {
+	h := newReplayHandlers(t)
+
+	shared, err := h.intersectLibraries([]string{testSteamID, testFriendID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sort.Slice(shared, func(i, j int) bool { return shared[i].AppID < shared[j].AppID })
+
+	// Half-Life 2 and Counter-Strike are only owned by one of them
+	if len(shared) != 2 || shared[0].AppID != 400 || shared[1].AppID != 620 {
+		t.Fatalf("expected Portal and Portal 2 to be shared, got %+v", shared)
+	}
+	portal2 := shared[1]
+	if portal2.Playtimes[testSteamID] != 2890 || portal2.Playtimes[testFriendID] != 1200 || portal2.CombinedPlaytime != 4090 {
+		t.Errorf("unexpected playtimes %+v", portal2)
+	}
+
+	_, err = h.intersectLibraries([]string{testSteamID, testPrivateFriendID})
+	if !errors.Is(err, errPrivateProfile) {
+		t.Errorf("expected a private profile error, got %v", err)
+	}
+}
+
+func TestMultiplayerCategoryNames(t *testing.T) {
+	cases := []struct {
+		name       string
+		categories []steam.Category
+		want       []string
+	}{
+		{"single player", []steam.Category{{ID: 2, Description: "Single-player"}}, nil},
+		{"co-op", []steam.Category{{ID: 2, Description: "Single-player"}, {ID: 9, Description: "Co-op"}}, []string{"Co-op"}},
+		{"several", []steam.Category{{ID: 1, Description: "Multi-player"}, {ID: 49, Description: "PvP"}}, []string{"Multi-player", "PvP"}},
+		{"no categories", nil, nil},
+	}
+	for _, c := range cases {
+		if got := multiplayerCategoryNames(c.categories); !slices.Equal(got, c.want) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.want, got)
+		}
+	}
+}
+
+func TestReplayPlayTogether(t *testing.T) {
+	h := newReplayHandlers(t)
+	handler := h.LocaleMiddleware(http.HandlerFunc(h.HandlePlayTogether))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, authenticatedRequest(t, h, "/games/together?steamids="+testFriendID))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var games []SharedGame
+	if err := json.Unmarshal(rec.Body.Bytes(), &games); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Portal is shared but single player only
+	if len(games) != 1 || games[0].Name != "Portal 2" {
+		t.Fatalf("expected only Portal 2, got %+v", games)
+	}
+	if !slices.Equal(games[0].Categories, []string{"Co-op", "Online Co-op"}) {
+		t.Errorf("expected the multiplayer categories, got %v", games[0].Categories)
+	}
+}
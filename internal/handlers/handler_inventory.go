@@ -1,10 +1,12 @@
 package handlers
 
 import (
-	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/masintxi/gamehub/internal/steam"
 )
 
 // const (
@@ -14,80 +16,198 @@ import (
 // 	sessionName  = "steam-session"
 // )
 
+const (
+	// Steam Community items are used when no app inventory is requested
+	defaultInventoryAppID     = "753"
+	defaultInventoryContextID = "6"
+)
+
 func (h *SteamHandlers) HandleTradeInventory(w http.ResponseWriter, r *http.Request) {
 	// 1. Get session and validate
 	steamID, err := h.steamAuth.GetSteamID(r)
 	if err != nil {
-		http.Error(w, "Not authenticated", http.StatusUnauthorized)
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	appID, contextID, err := inventoryParams(r)
+	if err != nil {
+		respondWithError(w, r, errInvalidRequest, err.Error())
 		return
 	}
 
 	// 2. Make the request
-	url := fmt.Sprintf("https://api.steampowered.com/IEconService/GetInventoryItemsWithDescriptions/v1/?key=%s&steamid=%s&appid=753&contextid=6&get_descriptions=true",
-		h.steamAuth.GetAPIKey(), steamID)
+	url := fmt.Sprintf("https://api.steampowered.com/IEconService/GetInventoryItemsWithDescriptions/v1/?key=%s&steamid=%s&appid=%s&contextid=%s&get_descriptions=true",
+		h.steamAuth.GetAPIKey(), steamID, appID, contextID)
 
-	headers := map[string]string{
-		"User-Agent": "Mozilla/5.0",
+	bodyBytes, err := h.getResponseBody(url)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to read response")
+		return
+	}
+
+	// 3. Decode JSON into struct
+	var tradeResponse steam.TradeInventoryResponse
+	if err := decodeSteam(bodyBytes, &tradeResponse); err != nil {
+		respondWithError(w, r, err, "Failed to decode inventory")
+		return
+	}
+
+	items, err := steam.NewTradeInventoryItems(tradeResponse)
+	if err != nil {
+		respondWithError(w, r, fmt.Errorf("%w: %v", errDecode, err), "Failed to decode inventory")
+		return
 	}
 
-	bodyBytes, err := h.getResponseBody(url, headers)
+	// 4. Send response
+	respondWithJSON(w, http.StatusOK, items)
+}
+
+func (h *SteamHandlers) HandleInventory(w http.ResponseWriter, r *http.Request) {
+	// 1. Get session and validate
+	steamID, err := h.steamAuth.GetSteamID(r)
 	if err != nil {
-		log.Printf("Error reading response: %v", err)
-		http.Error(w, "Failed to read response", http.StatusInternalServerError)
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
 		return
 	}
 
-	// 5. Decode JSON into struct
-	var tradeResponse SteamTradeResponse
-	if err := json.Unmarshal(bodyBytes, &tradeResponse); err != nil {
-		log.Printf("Error decoding inventory: %v", err)
-		http.Error(w, "Failed to decode inventory", http.StatusInternalServerError)
+	appID, contextID, err := inventoryParams(r)
+	if err != nil {
+		respondWithError(w, r, errInvalidRequest, err.Error())
 		return
 	}
 
-	// Set response headers and send response
-	w.Header().Set("Content-Type", "application/json")
-	if _, err := w.Write(bodyBytes); err != nil {
-		log.Printf("Error writing response: %v", err)
+	// 2. Walk every inventory page
+	items, total, err := h.fetchInventory(steamID, appID, contextID, LocaleFromContext(r.Context()))
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch inventory")
 		return
 	}
+
+	// 3. Send response
+	respondWithJSON(w, http.StatusOK, InventoryResult{
+		SteamID:             steamID,
+		AppID:               appID,
+		ContextID:           contextID,
+		TotalInventoryCount: total,
+		Items:               items,
+	})
 }
 
-func (h *SteamHandlers) HandleInventory(w http.ResponseWriter, r *http.Request) {
+// HandleInventoryValue prices the marketable items of an inventory. Large
+// inventories are priced over several calls, see valueInventory.
+func (h *SteamHandlers) HandleInventoryValue(w http.ResponseWriter, r *http.Request) {
 	// 1. Get session and validate
 	steamID, err := h.steamAuth.GetSteamID(r)
 	if err != nil {
-		http.Error(w, "Not authenticated", http.StatusUnauthorized)
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
 		return
 	}
 
-	// 2. Make the request
-	url := fmt.Sprintf("https://steamcommunity.com/inventory/%s/753/6?l=english&count=10", steamID)
+	appID, contextID, err := inventoryParams(r)
+	if err != nil {
+		respondWithError(w, r, errInvalidRequest, err.Error())
+		return
+	}
 
-	headers := map[string]string{
-		"User-Agent": "Mozilla/5.0",
+	// 2. Walk every inventory page
+	loc := LocaleFromContext(r.Context())
+	items, _, err := h.fetchInventory(steamID, appID, contextID, loc)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch inventory")
+		return
 	}
 
-	// 4. Read body
-	bodyBytes, err := h.getResponseBody(url, headers)
+	// 3. Price the items
+	valuation := h.valueInventory(items, loc)
+	valuation.SteamID = steamID
+	valuation.AppID = appID
+	valuation.ContextID = contextID
+
+	// 4. Send response
+	respondWithJSON(w, http.StatusOK, valuation)
+}
+
+func (h *SteamHandlers) HandleInventoryApps(w http.ResponseWriter, r *http.Request) {
+	// 1. Get session and validate
+	steamID, err := h.steamAuth.GetSteamID(r)
 	if err != nil {
-		log.Printf("Error reading response: %v", err)
-		http.Error(w, "Failed to read response", http.StatusInternalServerError)
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
 		return
 	}
 
-	// 5. Decode JSON into struct
-	var inventory SteamInventoryResponse
-	if err := json.Unmarshal(bodyBytes, &inventory); err != nil {
-		log.Printf("Error decoding inventory: %v", err)
-		http.Error(w, "Failed to decode inventory", http.StatusInternalServerError)
+	// 2. Discover the app inventories of the profile
+	apps, err := h.fetchInventoryApps(steamID, LocaleFromContext(r.Context()))
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch inventory apps")
 		return
 	}
 
-	// 7. Send response
-	w.Header().Set("Content-Type", "application/json")
-	if _, err := w.Write(bodyBytes); err != nil {
-		log.Printf("Error writing response: %v", err)
+	// 3. Send response
+	respondWithJSON(w, http.StatusOK, apps)
+}
+
+func (h *SteamHandlers) HandleAllInventories(w http.ResponseWriter, r *http.Request) {
+	// 1. Get session and validate
+	steamID, err := h.steamAuth.GetSteamID(r)
+	if err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
 		return
 	}
+
+	// 2. Discover the app inventories of the profile
+	apps, err := h.fetchInventoryApps(steamID, LocaleFromContext(r.Context()))
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch inventory apps")
+		return
+	}
+
+	// 3. Walk every context of every app
+	result := AllInventoriesResult{
+		SteamID:     steamID,
+		Inventories: []InventoryResult{},
+	}
+	for _, app := range apps {
+		appID := strconv.Itoa(app.AppID)
+		for _, ctx := range app.Contexts {
+			if ctx.AssetCount == 0 {
+				continue
+			}
+
+			items, total, err := h.fetchInventory(steamID, appID, ctx.ID, LocaleFromContext(r.Context()))
+			if err != nil {
+				respondWithError(w, r, err, "Failed to fetch inventory")
+				return
+			}
+
+			result.Inventories = append(result.Inventories, InventoryResult{
+				SteamID:             steamID,
+				AppID:               appID,
+				ContextID:           ctx.ID,
+				TotalInventoryCount: total,
+				Items:               items,
+			})
+			result.TotalItems += len(items)
+		}
+	}
+
+	// 4. Send response
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+func inventoryParams(r *http.Request) (string, string, error) {
+	appID := chi.URLParam(r, "appid")
+	contextID := chi.URLParam(r, "contextid")
+	if appID == "" && contextID == "" {
+		return defaultInventoryAppID, defaultInventoryContextID, nil
+	}
+
+	if _, err := strconv.ParseUint(appID, 10, 32); err != nil {
+		return "", "", fmt.Errorf("invalid appid: %q", appID)
+	}
+	if _, err := strconv.ParseUint(contextID, 10, 64); err != nil {
+		return "", "", fmt.Errorf("invalid contextid: %q", contextID)
+	}
+
+	return appID, contextID, nil
 }
@@ -1,233 +1,200 @@
 package handlers
 
 import (
-	"compress/gzip"
-	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"log"
 	"net/http"
 	"net/url"
-	"time"
+	"strconv"
 
-	"github.com/chromedp/chromedp"
-	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/v5"
 )
 
-type MarketPriceHistory struct {
-	Success      bool            `json:"success"`
-	PriceHistory [][]interface{} `json:"price_history"`
-	// Each price history entry is [timestamp, price, volume]
-}
-
-func (h *SteamHandlers) HandleMarketItem(w http.ResponseWriter, r *http.Request) {
-	itemName := chi.URLParam(r, "item_name")
-	log.Println("itemName: ", itemName)
-	if itemName == "" {
-		itemName = "Frifle and Mauser"
-	}
-
-	url := fmt.Sprintf("https://steamcommunity.com/market/listings/753/%s", url.QueryEscape(itemName))
-	log.Println(url)
+const (
+	defaultOrderBookLevels = 10
+	defaultListingsCount   = 10
+	maxListingsCount       = 100
 
-	opts := append(chromedp.DefaultExecAllocatorOptions[:],
-		chromedp.ExecPath(`/mnt/c/Program Files/Google/Chrome/Application/chrome.exe`),
-		chromedp.Headless,                     // Run in headless mode
-		chromedp.Flag("enable-logging", "v1"), // Enable logging
-	)
-
-	// Create a context with the custom options
-	allocCtx, cancel := chromedp.NewExecAllocator(context.Background(), opts...)
-	defer cancel()
+	maxNameIDBatch = 100
+)
 
-	// Create a new browser context
-	ctx, cancel := chromedp.NewContext(allocCtx)
-	defer cancel()
+func (h *SteamHandlers) HandleMarketOverview(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.steamAuth.GetSteamID(r); err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
 
-	// Set a timeout for the operation
-	ctx, cancel = context.WithTimeout(ctx, 60*time.Second) // Increase timeout
-	defer cancel()
+	appID, marketHashName, err := marketItemParams(r)
+	if err != nil {
+		respondWithError(w, r, errInvalidRequest, err.Error())
+		return
+	}
 
-	// Variable to store the extracted price history
-	var priceHistory string
+	overview, err := h.fetchPriceOverview(appID, marketHashName, LocaleFromContext(r.Context()))
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch price overview")
+		return
+	}
 
-	// Run the chromedp tasks
-	err := chromedp.Run(ctx,
-		// Navigate to the Steam Community Market page
-		chromedp.Navigate("https://steamcommunity.com/market/listings/753/Frifle%20and%20Mauser"),
+	respondWithJSON(w, http.StatusOK, overview)
+}
 
-		// Wait for the page to load
-		chromedp.WaitVisible(`#tabContentsMyActiveMarketListings`, chromedp.ByID),
+func (h *SteamHandlers) HandleMarketOrderBook(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.steamAuth.GetSteamID(r); err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
 
-		// Extract the price history variable
-		chromedp.Evaluate(`JSON.stringify(line1);`, &priceHistory),
-	)
+	appID, marketHashName, err := marketItemParams(r)
 	if err != nil {
-		log.Fatalf("Failed to run chromedp tasks: %v", err)
+		respondWithError(w, r, errInvalidRequest, err.Error())
+		return
+	}
+	levels := defaultOrderBookLevels
+	if l := r.URL.Query().Get("levels"); l != "" {
+		levels, err = strconv.Atoi(l)
+		if err != nil || levels < 1 {
+			respondWithError(w, r, errInvalidRequest, "Invalid levels, expected a positive number")
+			return
+		}
 	}
 
-	// Parse the price history data
-	var history [][]interface{}
-	if err := json.Unmarshal([]byte(priceHistory), &history); err != nil {
-		log.Fatalf("Failed to parse price history: %v", err)
+	// 1. Find the item_nameid of the item
+	nameID, err := h.resolveNameID(appID, marketHashName)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to resolve item")
+		return
 	}
 
-	// Print the price history
-	fmt.Println("Price History:")
-	for _, entry := range history {
-		fmt.Printf("Date: %s, Price: %v, Volume: %v\n", entry[0], entry[1], entry[2])
+	// 2. Fetch and read the order book
+	book, err := h.fetchOrderBook(nameID, LocaleFromContext(r.Context()))
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch order book")
+		return
 	}
+	book.AppID = appID
+	book.MarketHashName = marketHashName
+	book.Bids = book.Bids[:min(levels, len(book.Bids))]
+	book.Asks = book.Asks[:min(levels, len(book.Asks))]
 
-	fmt.Fprintf(w, "%s", history[0][1])
+	// 3. Send response
+	respondWithJSON(w, http.StatusOK, book)
 }
 
-func (h *SteamHandlers) HandleMarketData(w http.ResponseWriter, r *http.Request) {
-	// First, get the user's session
-	session, err := h.steamAuth.GetSession(r)
+// HandleMarketHistory is the only public market route. It reads the same
+// listing page anyone can open, cached and spaced out by the rate limit of
+// the Steam Community host.
+func (h *SteamHandlers) HandleMarketHistory(w http.ResponseWriter, r *http.Request) {
+	appID, marketHashName, err := marketItemParams(r)
 	if err != nil {
-		log.Printf("Session error: %v", err)
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		respondWithError(w, r, errInvalidRequest, err.Error())
 		return
 	}
 
-	// Check if user is authenticated
-	if session.Values["steamID"] == nil {
-		http.Error(w, "Not logged in", http.StatusUnauthorized)
+	// 1. Read the history from the listing page
+	listing, err := h.fetchListingPage(appID, marketHashName, LocaleFromContext(r.Context()))
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch price history")
 		return
 	}
 
-	// marketHashName := chi.URLParam(r, "market_hash_name")
-	// if marketHashName == "" {
-	// 	http.Error(w, "Market hash name is required", http.StatusBadRequest)
-	// 	return
-	// }
-	// marketHashName = url.QueryEscape(marketHashName)
-	// marketHashName = strings.ReplaceAll(marketHashName, "+", "%20")
+	// 2. Send response
+	respondWithJSON(w, http.StatusOK, MarketHistory{
+		AppID:          appID,
+		MarketHashName: marketHashName,
+		NameID:         listing.NameID,
+		Currency:       listing.Currency,
+		History:        listing.History,
+	})
+}
 
-	// Steam Market API URL for price history
-	// url := fmt.Sprintf("https://steamcommunity.com/market/pricehistory/?appid=753&market_hash_name=%s", url.QueryEscape(marketHashName))
+func (h *SteamHandlers) HandleMarketListings(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.steamAuth.GetSteamID(r); err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
 
-	//baseURL := fmt.Sprintf("https://steamcommunity.com/market/listings/753/%s/render/history", marketHashName)
-	baseURL := "https://steamcommunity.com/market/itemordershistogram"
-	u, err := url.Parse(baseURL)
+	appID, marketHashName, err := marketItemParams(r)
 	if err != nil {
-		http.Error(w, "Failed to parse URL", http.StatusInternalServerError)
+		respondWithError(w, r, errInvalidRequest, err.Error())
 		return
 	}
 
-	q := u.Query()
-	q.Add("country", "ES")
-	q.Add("language", "english")
-	q.Add("currency", "3") // 3 is for EUR
-	q.Add("item_nameid", "150084592")
-	u.RawQuery = q.Encode()
+	query := r.URL.Query()
+	start := 0
+	if s := query.Get("start"); s != "" {
+		start, err = strconv.Atoi(s)
+		if err != nil || start < 0 {
+			respondWithError(w, r, errInvalidRequest, "Invalid start, expected a number")
+			return
+		}
+	}
+	count := defaultListingsCount
+	if c := query.Get("count"); c != "" {
+		count, err = strconv.Atoi(c)
+		if err != nil || count < 1 || count > maxListingsCount {
+			respondWithError(w, r, errInvalidRequest, fmt.Sprintf("Invalid count, expected 1 to %d", maxListingsCount))
+			return
+		}
+	}
 
-	req, err := http.NewRequest("GET", u.String(), nil)
+	listings, err := h.fetchListings(appID, marketHashName, start, count, LocaleFromContext(r.Context()))
 	if err != nil {
-		http.Error(w, "Failed to create request", http.StatusInternalServerError)
-		return
-	}
-
-	for _, cookie := range r.Cookies() {
-		req.AddCookie(cookie)
-	}
-	// Add more complete headers
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/133.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Accept-Encoding", "gzip, deflate, br")
-	req.Header.Set("Connection", "keep-alive")
-	req.Header.Set("Cache-Control", "max-age=0")
-	req.Header.Set("Sec-Fetch-Dest", "document")
-	req.Header.Set("Sec-Fetch-Mode", "navigate")
-	req.Header.Set("Sec-Fetch-Site", "same-origin")
-	req.Header.Set("Sec-Fetch-User", "?1")
-	req.Header.Set("Upgrade-Insecure-Requests", "1")
-
-	// Add the specific Steam cookies
-	if sessionID, ok := session.Values["sessionid"].(string); ok {
-		req.AddCookie(&http.Cookie{
-			Name:  "sessionid",
-			Value: sessionID,
-		})
-	} else {
-		log.Printf("sessionid cookie not found")
-	}
-
-	// Add steamCountry cookie
-	if steamCountry, ok := session.Values["steamCountry"].(string); ok {
-		req.AddCookie(&http.Cookie{
-			Name:  "steamCountry",
-			Value: steamCountry,
-		})
-	} else {
-		log.Printf("steamCountry cookie not found")
-	}
-
-	if steamLoginSecure, ok := session.Values["steamLoginSecure"].(string); ok {
-		req.AddCookie(&http.Cookie{
-			Name:  "steamLoginSecure",
-			Value: steamLoginSecure,
-		})
-	} else {
-		log.Printf("steamLoginSecure cookie not found")
-	}
-
-	// Add timezoneOffset cookie
-	req.AddCookie(&http.Cookie{
-		Name:  "timezoneOffset",
-		Value: "3600,0",
-	})
-
-	// Create a client that handles gzip compression
-	client := &http.Client{
-		Transport: &http.Transport{
-			DisableCompression: false,
-		},
-		Timeout: 30 * time.Second,
+		respondWithError(w, r, err, "Failed to fetch listings")
+		return
 	}
 
-	// log.Printf("Requesting URL: %s", u.String())
-	// log.Printf("With cookies: %v", req.Cookies())
+	respondWithJSON(w, http.StatusOK, listings)
+}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		http.Error(w, "Failed to fetch market data", http.StatusInternalServerError)
+// HandleResolveNameIDs resolves the item_nameid of a list of items ahead of
+// time, so later order book calls don't have to read their listing pages.
+func (h *SteamHandlers) HandleResolveNameIDs(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.steamAuth.GetSteamID(r); err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
 		return
 	}
-	defer resp.Body.Close()
-
-	// if resp.StatusCode != http.StatusOK {
-	// 	log.Printf("Steam Error - Status: %d", resp.StatusCode)
-	// 	log.Printf("Steam Error - Headers: %v", resp.Header)
-	// 	log.Printf("Steam Error - URL: %s", baseURL)
-	// }
 
-	var body []byte
-	if resp.Header.Get("Content-Encoding") == "gzip" {
-		reader, err := gzip.NewReader(resp.Body)
-		if err != nil {
-			log.Printf("Error creating gzip reader: %v", err)
-			return
-		}
-		defer reader.Close()
-		body, err = io.ReadAll(reader)
-		if err != nil {
-			log.Printf("Error reading gzipped body: %v", err)
-			return
-		}
-	} else {
-		body, err = io.ReadAll(resp.Body)
-		if err != nil {
-			log.Printf("Error reading body: %v", err)
+	var items []MarketItemNameID
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		respondWithError(w, r, errInvalidRequest, "Invalid body, expected a list of appid and market_hash_name")
+		return
+	}
+	if len(items) > maxNameIDBatch {
+		respondWithError(w, r, errInvalidRequest, fmt.Sprintf("Too many items, at most %d per call", maxNameIDBatch))
+		return
+	}
+	for _, item := range items {
+		if item.AppID <= 0 || item.MarketHashName == "" {
+			respondWithError(w, r, errInvalidRequest, "Every item needs an appid and a market_hash_name")
 			return
 		}
 	}
 
-	// log.Printf("Response Status: %d", resp.StatusCode)
-	// log.Printf("Response Body: %s", string(body))
+	resolved, err := h.resolveNameIDs(items)
+	if err != nil {
+		respondWithError(w, r, err, fmt.Sprintf("Resolved %d of %d items, retry later to resume", len(resolved), len(items)))
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, resolved)
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.Write(body)
+// marketItemParams reads the item of the /market/{appid}/{market_hash_name}
+// routes. Names keep the escaping of the URL when the client encoded more
+// than Go would, as with parentheses.
+func marketItemParams(r *http.Request) (int, string, error) {
+	appID, err := strconv.Atoi(chi.URLParam(r, "appid"))
+	if err != nil || appID <= 0 {
+		return 0, "", fmt.Errorf("invalid appid %q", chi.URLParam(r, "appid"))
+	}
+
+	marketHashName := chi.URLParam(r, "market_hash_name")
+	if unescaped, err := url.PathUnescape(marketHashName); err == nil {
+		marketHashName = unescaped
+	}
+	if marketHashName == "" {
+		return 0, "", fmt.Errorf("market hash name is required")
+	}
+	return appID, marketHashName, nil
 }
@@ -0,0 +1,11 @@
+package handlers
+
+import (
+	"net/http"
+)
+
+// HandleUpstreamMetrics reports the requests made to each Steam host since
+// the server started. It is only routed when DEBUG_ENDPOINTS is set.
+func (h *SteamHandlers) HandleUpstreamMetrics(w http.ResponseWriter, r *http.Request) {
+	respondWithJSON(w, http.StatusOK, h.client.Metrics.Snapshot())
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+const (
+	defaultNewsGames   = 10
+	maxNewsGames       = 50
+	newsItemsPerApp    = 10
+	defaultNewsPerPage = 20
+	maxNewsPerPage     = 100
+	maxNewsPage        = 1000
+
+	// GetNewsForApp calls run in parallel but share one request budget
+	newsWorkers         = 4
+	newsRequestInterval = 250 * time.Millisecond
+)
+
+func (h *SteamHandlers) HandleNews(w http.ResponseWriter, r *http.Request) {
+	// 1. Get session and validate
+	steamID, err := h.steamAuth.GetSteamID(r)
+	if err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	query := r.URL.Query()
+	gameCount := intParam(query.Get("games"), defaultNewsGames)
+	if gameCount > maxNewsGames {
+		gameCount = maxNewsGames
+	}
+	page := min(intParam(query.Get("page"), 1), maxNewsPage)
+	perPage := min(intParam(query.Get("per_page"), defaultNewsPerPage), maxNewsPerPage)
+
+	feed := query.Get("feed")
+	switch feed {
+	case "", "patch", "press", "community":
+	default:
+		respondWithError(w, r, errInvalidRequest, "Invalid feed, expected patch, press or community")
+		return
+	}
+
+	// 2. Most played games of the library
+	ownedGames, err := h.fetchOwnedGames(steamID)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch owned games")
+		return
+	}
+
+	sort.SliceStable(ownedGames, func(i, j int) bool {
+		return ownedGames[i].Playtime > ownedGames[j].Playtime
+	})
+	if len(ownedGames) > gameCount {
+		ownedGames = ownedGames[:gameCount]
+	}
+
+	// 3. Fetch, filter and de-duplicate the news
+	items, err := h.fetchNewsForApps(ownedGames)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch news")
+		return
+	}
+
+	filtered := make([]NewsItem, 0, len(items))
+	for _, item := range items {
+		if matchesNewsFeed(item, feed) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Date.After(filtered[j].Date)
+	})
+
+	// 4. Paginate and send response
+	respondWithJSON(w, http.StatusOK, NewsFeed{
+		Page:    page,
+		PerPage: perPage,
+		Total:   len(filtered),
+		Items:   newsPage(filtered, page, perPage),
+	})
+}
+
+// newsPage returns the items of a page, checking the page against the
+// number of pages before computing the offset so huge values can't overflow.
+func newsPage(items []NewsItem, page, perPage int) []NewsItem {
+	pages := (len(items) + perPage - 1) / perPage
+	if page < 1 || page > pages {
+		return []NewsItem{}
+	}
+	start := (page - 1) * perPage
+	return items[start:min(start+perPage, len(items))]
+}
+
+// fetchNewsForApps fetches the news of every game with a small pool of
+// workers. The ticker of the handlers spaces out the calls that miss the
+// cache, so neither the pool nor concurrent requests go over the Web API
+// rate limit.
+func (h *SteamHandlers) fetchNewsForApps(games []steam.OwnedGame) ([]NewsItem, error) {
+	jobs := make(chan steam.OwnedGame)
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		items    []NewsItem
+		firstErr error
+		seen     = map[string]bool{}
+	)
+
+	for i := 0; i < newsWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for game := range jobs {
+				appNews, err := h.fetchAppNews(game.AppID)
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+					continue
+				}
+				for _, n := range appNews.Appnews.Newsitems {
+					// The same post is often shared by a game and its DLCs
+					if seen[n.Gid] || (n.URL != "" && seen[n.URL]) {
+						continue
+					}
+					seen[n.Gid] = true
+					if n.URL != "" {
+						seen[n.URL] = true
+					}
+					items = append(items, newNewsItem(n, game.Name))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, game := range games {
+		jobs <- game
+	}
+	close(jobs)
+	wg.Wait()
+
+	// A game without news shouldn't hide the rest of the feed
+	if len(items) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	if firstErr != nil {
+		log.Printf("Error fetching some news: %v", firstErr)
+	}
+
+	return items, nil
+}
+
+func (h *SteamHandlers) fetchAppNews(appID int) (steam.AppNewsResponse, error) {
+	url := fmt.Sprintf("https://api.steampowered.com/ISteamNews/GetNewsForApp/v2/?appid=%d&count=%d&maxlength=300&format=json",
+		appID, newsItemsPerApp)
+
+	if _, ok := h.client.Cache.Get(url); !ok {
+		<-h.newsLimiter.C
+	}
+
+	bodyBytes, err := h.getResponseBody(url)
+	if err != nil {
+		return steam.AppNewsResponse{}, err
+	}
+
+	var appNews steam.AppNewsResponse
+	if err := decodeSteam(bodyBytes, &appNews); err != nil {
+		return steam.AppNewsResponse{}, fmt.Errorf("decoding news of %d: %w", appID, err)
+	}
+
+	return appNews, nil
+}
+
+func newNewsItem(n steam.AppNewsItem, gameName string) NewsItem {
+	return NewsItem{
+		Gid:        n.Gid,
+		AppID:      n.AppID,
+		GameName:   gameName,
+		Title:      n.Title,
+		URL:        n.URL,
+		Author:     n.Author,
+		Contents:   n.Contents,
+		FeedLabel:  n.Feedlabel,
+		FeedName:   n.Feedname,
+		External:   n.IsExternalURL,
+		FeedType:   n.FeedType,
+		PatchNotes: slices.Contains(n.Tags, "patchnotes"),
+		Date:       time.Unix(n.Date, 0).UTC(),
+	}
+}
+
+// matchesNewsFeed filters by feed type. Steam marks press articles with
+// feed_type 0 and its own community announcements with feed_type 1.
+func matchesNewsFeed(item NewsItem, feed string) bool {
+	switch feed {
+	case "patch":
+		return item.PatchNotes
+	case "press":
+		return item.FeedType == 0
+	case "community":
+		return item.FeedType == 1
+	}
+	return true
+}
+
+func intParam(value string, fallback int) int {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return fallback
+	}
+	return n
+}
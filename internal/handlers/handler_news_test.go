@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewsPage(t *testing.T) {
+	items := make([]NewsItem, 5)
+	for i := range items {
+		items[i].Gid = string(rune('a' + i))
+	}
+
+	cases := []struct {
+		page, perPage int
+		want          string
+	}{
+		{1, 2, "ab"},
+		{3, 2, "e"},
+		{4, 2, ""},
+		{1, 10, "abcde"},
+		{math.MaxInt, 2, ""},
+		{math.MaxInt / 2, 3, ""},
+	}
+	for _, c := range cases {
+		got := ""
+		for _, item := range newsPage(items, c.page, c.perPage) {
+			got += item.Gid
+		}
+		if got != c.want {
+			t.Errorf("newsPage(page %d, per page %d) = %q, want %q", c.page, c.perPage, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+const (
+	playerCountKeyPrefix = "players:"
+
+	// Samples kept per game, about six weeks at the default interval
+	maxPlayerCountSamples = 2000
+
+	// Games sampled by the background job. Counts of games past the limit
+	// are still served, just not recorded.
+	maxTrackedPlayerCounts = 500
+
+	// A sample younger than this is served instead of asking Steam again
+	playerCountFreshness = 10 * time.Minute
+
+	// Library games counted per request, the most played first
+	maxLibraryPlayerCounts = 50
+
+	playerCountWorkers  = 4
+	defaultPlayersSince = 7 * 24 * time.Hour
+)
+
+func (h *SteamHandlers) HandleGamePlayers(w http.ResponseWriter, r *http.Request) {
+	// Looking at a game starts tracking it, so only users can do it
+	if _, err := h.steamAuth.GetSteamID(r); err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	appID, err := h.resolveAppID(chi.URLParam(r, "appid"))
+	if err != nil {
+		respondWithError(w, r, fmt.Errorf("%w: %v", errNotFound, err), err.Error())
+		return
+	}
+
+	since := defaultPlayersSince
+	if s := r.URL.Query().Get("since"); s != "" {
+		since, err = time.ParseDuration(s)
+		if err != nil || since <= 0 {
+			respondWithError(w, r, errInvalidRequest, "Invalid since, expected a duration like 24h")
+			return
+		}
+	}
+
+	// 1. Current count, which also records a sample
+	current, err := h.currentPlayers(appID)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch player count")
+		return
+	}
+
+	// 2. History of the requested window
+	var history []PlayerCountSample
+	if _, err := h.client.Store.Get(playerCountKey(appID), &history); err != nil {
+		log.Printf("Error reading player count history: %v", err)
+	}
+
+	report := PlayerCountReport{
+		AppID:   appID,
+		Current: current,
+		Samples: []PlayerCountSample{},
+	}
+
+	cutoff := time.Now().Add(-since)
+	total := 0
+	for _, sample := range history {
+		if sample.Time.Before(cutoff) {
+			continue
+		}
+		if len(report.Samples) == 0 || sample.Count < report.Low {
+			report.Low = sample.Count
+		}
+		if sample.Count > report.Peak {
+			report.Peak = sample.Count
+		}
+		total += sample.Count
+		report.Samples = append(report.Samples, sample)
+	}
+	if len(report.Samples) > 0 {
+		report.Average = total / len(report.Samples)
+	}
+
+	// 3. Send response
+	respondWithJSON(w, http.StatusOK, report)
+}
+
+// currentPlayers returns the latest sample when it is fresh enough and takes
+// a new one otherwise.
+func (h *SteamHandlers) currentPlayers(appID int) (int, error) {
+	var history []PlayerCountSample
+	if _, err := h.client.Store.Get(playerCountKey(appID), &history); err != nil {
+		return 0, err
+	}
+
+	if n := len(history); n > 0 && time.Since(history[n-1].Time) < playerCountFreshness {
+		return history[n-1].Count, nil
+	}
+
+	return h.samplePlayerCount(appID)
+}
+
+// addCurrentPlayers fills the player count column of the most played games
+// of the library. Counts aren't recorded, so looking at a library doesn't
+// start tracking its games. Games past the cap and games Steam has no count
+// for are left empty.
+func (h *SteamHandlers) addCurrentPlayers(games []LibraryGame) {
+	counted := make([]*LibraryGame, len(games))
+	for i := range games {
+		counted[i] = &games[i]
+	}
+	sort.SliceStable(counted, func(i, j int) bool {
+		return counted[i].PlaytimeForever > counted[j].PlaytimeForever
+	})
+	if len(counted) > maxLibraryPlayerCounts {
+		counted = counted[:maxLibraryPlayerCounts]
+	}
+
+	sem := make(chan struct{}, playerCountWorkers)
+	var wg sync.WaitGroup
+
+	for _, game := range counted {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(game *LibraryGame) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			count, err := h.libraryPlayers(game.AppID)
+			if err != nil {
+				return
+			}
+			game.CurrentPlayers = &count
+		}(game)
+	}
+
+	wg.Wait()
+}
+
+// libraryPlayers returns the latest sample of a tracked game when it is
+// fresh enough, and a cached count otherwise.
+func (h *SteamHandlers) libraryPlayers(appID int) (int, error) {
+	var history []PlayerCountSample
+	if _, err := h.client.Store.Get(playerCountKey(appID), &history); err != nil {
+		return 0, err
+	}
+
+	if n := len(history); n > 0 && time.Since(history[n-1].Time) < playerCountFreshness {
+		return history[n-1].Count, nil
+	}
+
+	bodyBytes, err := h.getResponseBodyWithTTL(playerCountURL(appID), playerCountFreshness)
+	if err != nil {
+		return 0, err
+	}
+	return parsePlayerCount(appID, bodyBytes)
+}
+
+// samplePlayerCounts is the background job refreshing every game that has
+// been looked at before.
+func (h *SteamHandlers) samplePlayerCounts() {
+	for _, appID := range h.trackedPlayers.list() {
+		if _, err := h.samplePlayerCount(appID); err != nil {
+			log.Printf("Error sampling player count of %d: %v", appID, err)
+		}
+	}
+}
+
+// samplePlayerCount asks Steam for the current count and records it, which
+// starts tracking the game while there is room.
+func (h *SteamHandlers) samplePlayerCount(appID int) (int, error) {
+	// Counts change all the time, so they skip the cache
+	bodyBytes, err := h.fetchResponseBody(playerCountURL(appID))
+	if err != nil {
+		return 0, err
+	}
+	count, err := parsePlayerCount(appID, bodyBytes)
+	if err != nil {
+		return 0, err
+	}
+
+	if !h.trackedPlayers.track(appID) {
+		return count, nil
+	}
+
+	var history []PlayerCountSample
+	err = h.client.Store.Update(playerCountKey(appID), &history, func() error {
+		history = append(history, PlayerCountSample{
+			Time:  time.Now().UTC(),
+			Count: count,
+		})
+		if len(history) > maxPlayerCountSamples {
+			history = history[len(history)-maxPlayerCountSamples:]
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error saving player count of %d: %v", appID, err)
+	}
+
+	return count, nil
+}
+
+func playerCountURL(appID int) string {
+	return fmt.Sprintf("https://api.steampowered.com/ISteamUserStats/GetNumberOfCurrentPlayers/v1/?appid=%d", appID)
+}
+
+func parsePlayerCount(appID int, bodyBytes []byte) (int, error) {
+	var countResponse steam.PlayerCountResponse
+	if err := decodeSteam(bodyBytes, &countResponse); err != nil {
+		return 0, fmt.Errorf("decoding player count: %w", err)
+	}
+	if countResponse.Response.Result != 1 {
+		return 0, fmt.Errorf("no player count for %d: result %d", appID, countResponse.Response.Result)
+	}
+
+	return countResponse.Response.PlayerCount, nil
+}
+
+// trackedGames is the set of games whose player counts are recorded, kept
+// in memory so sampling doesn't list the store every time.
+type trackedGames struct {
+	mu    sync.Mutex
+	limit int
+	apps  map[int]bool
+}
+
+// newTrackedGames starts from the games that already have a history.
+func newTrackedGames(keys []string, limit int) *trackedGames {
+	t := &trackedGames{limit: limit, apps: make(map[int]bool, len(keys))}
+	for _, key := range keys {
+		appID, err := strconv.Atoi(strings.TrimPrefix(key, playerCountKeyPrefix))
+		if err != nil {
+			continue
+		}
+		t.apps[appID] = true
+	}
+	return t
+}
+
+// track reports whether the game is tracked, adding it when there is room.
+func (t *trackedGames) track(appID int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.apps[appID] {
+		return true
+	}
+	if len(t.apps) >= t.limit {
+		return false
+	}
+	t.apps[appID] = true
+	return true
+}
+
+func (t *trackedGames) list() []int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return slices.Sorted(maps.Keys(t.apps))
+}
+
+func playerCountKey(appID int) string {
+	return playerCountKeyPrefix + strconv.Itoa(appID)
+}
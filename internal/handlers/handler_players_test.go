@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestTrackedGames(t *testing.T) {
+	tracked := newTrackedGames([]string{"players:620", "players:oops"}, 2)
+
+	if !tracked.track(620) {
+		t.Error("expected a game with a history to be tracked")
+	}
+	if !tracked.track(400) {
+		t.Error("expected a new game to be tracked while there is room")
+	}
+	if tracked.track(220) {
+		t.Error("expected no new game to be tracked past the limit")
+	}
+	if got := tracked.list(); !slices.Equal(got, []int{400, 620}) {
+		t.Errorf("expected games 400 and 620, got %v", got)
+	}
+}
+
+func TestReplayLibraryPlayersNotTracked(t *testing.T) {
+	h := newReplayHandlers(t)
+	handler := h.LocaleMiddleware(http.HandlerFunc(h.HandleUserGames))
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, authenticatedRequest(t, h, "/user-games?sort=players"))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		var games []LibraryGame
+		if err := json.Unmarshal(rec.Body.Bytes(), &games); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(games) != 3 || games[0].Name != "Portal 2" || games[0].CurrentPlayers == nil || *games[0].CurrentPlayers != 4120 {
+			t.Fatalf("expected Portal 2 to have the most players, got %+v", games)
+		}
+	}
+
+	// The second view must come from the cache
+	if requests := h.client.Metrics.Snapshot()["api.steampowered.com"].Requests; requests != 4 {
+		t.Errorf("expected 4 upstream requests, got %d", requests)
+	}
+	if tracked := h.trackedPlayers.list(); len(tracked) != 0 {
+		t.Errorf("expected a library view to track no games, got %v", tracked)
+	}
+	if keys := h.client.Store.Keys(playerCountKeyPrefix); len(keys) != 0 {
+		t.Errorf("expected no stored player counts, got %v", keys)
+	}
+}
+
+func TestReplayGamePlayersTracked(t *testing.T) {
+	h := newReplayHandlers(t)
+	router := chi.NewRouter()
+	router.Get("/games/{appid}/players", h.HandleGamePlayers)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, authenticatedRequest(t, h, "/games/620/players"))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var report PlayerCountReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Current != 4120 || len(report.Samples) != 1 {
+		t.Errorf("expected the current count to be recorded, got %+v", report)
+	}
+	if tracked := h.trackedPlayers.list(); !slices.Equal(tracked, []int{620}) {
+		t.Errorf("expected game 620 to be tracked, got %v", tracked)
+	}
+}
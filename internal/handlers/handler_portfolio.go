@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+)
+
+const defaultPortfolioSince = 30 * 24 * time.Hour
+
+func (h *SteamHandlers) HandlePortfolio(w http.ResponseWriter, r *http.Request) {
+	// 1. Get session and validate
+	steamID, err := h.steamAuth.GetSteamID(r)
+	if err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	appID, contextID, err := inventoryParams(r)
+	if err != nil {
+		respondWithError(w, r, errInvalidRequest, err.Error())
+		return
+	}
+
+	since := defaultPortfolioSince
+	if s := r.URL.Query().Get("since"); s != "" {
+		since, err = time.ParseDuration(s)
+		if err != nil || since <= 0 {
+			respondWithError(w, r, errInvalidRequest, "Invalid since, expected a duration like 168h")
+			return
+		}
+	}
+
+	// 2. Load the stored snapshots
+	var portfolio Portfolio
+	ok, err := h.client.Store.Get(portfolioKey(steamID, appID, contextID), &portfolio)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to read portfolio")
+		return
+	}
+	if !ok {
+		respondWithError(w, r, errNotFound, "Portfolio not tracked yet")
+		return
+	}
+
+	// 3. Send response
+	respondWithJSON(w, http.StatusOK, newPortfolioReport(portfolio, time.Now().Add(-since)))
+}
+
+// HandleTrackPortfolio starts taking snapshots of an inventory. The first one
+// is taken in the background, since large inventories need a few minutes.
+func (h *SteamHandlers) HandleTrackPortfolio(w http.ResponseWriter, r *http.Request) {
+	// 1. Get session and validate
+	steamID, err := h.steamAuth.GetSteamID(r)
+	if err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	appID, contextID, err := inventoryParams(r)
+	if err != nil {
+		respondWithError(w, r, errInvalidRequest, err.Error())
+		return
+	}
+
+	// 2. Save the portfolio and take its first snapshot
+	portfolio, err := h.trackPortfolio(steamID, appID, contextID, LocaleFromContext(r.Context()))
+	if err != nil {
+		respondWithError(w, r, err, "Failed to track portfolio")
+		return
+	}
+
+	go func() {
+		if err := h.snapshotPortfolio(steamID, appID, contextID); err != nil {
+			log.Printf("Error taking portfolio snapshot of %s: %v", portfolioKey(steamID, appID, contextID), err)
+		}
+	}()
+
+	// 3. Send response
+	respondWithJSON(w, http.StatusAccepted, newPortfolioReport(portfolio, time.Now()))
+}
+
+func (h *SteamHandlers) HandleUntrackPortfolio(w http.ResponseWriter, r *http.Request) {
+	steamID, err := h.steamAuth.GetSteamID(r)
+	if err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	appID, contextID, err := inventoryParams(r)
+	if err != nil {
+		respondWithError(w, r, errInvalidRequest, err.Error())
+		return
+	}
+
+	if err := h.client.Store.Delete(portfolioKey(steamID, appID, contextID)); err != nil {
+		respondWithError(w, r, err, "Failed to delete portfolio")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
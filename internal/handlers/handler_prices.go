@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// HandlePrices is public, so it takes as many apps as one appdetails
+	// call can price
+	maxPriceAppIDs = priceBatchSize
+
+	// Storefronts compared by one regional prices request, each one is a call
+	maxRegionalCountries = 20
+)
+
+func (h *SteamHandlers) HandlePrices(w http.ResponseWriter, r *http.Request) {
+	cc := LocaleFromContext(r.Context()).Country
+
+	appIDs := []int{}
+	for _, id := range strings.Split(r.URL.Query().Get("appids"), ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		appID, err := strconv.Atoi(id)
+		if err != nil {
+			respondWithError(w, r, errInvalidRequest, "Invalid appid: "+id)
+			return
+		}
+		appIDs = append(appIDs, appID)
+	}
+	if len(appIDs) == 0 {
+		respondWithError(w, r, errInvalidRequest, "Query parameter appids is required")
+		return
+	}
+	if len(appIDs) > maxPriceAppIDs {
+		respondWithError(w, r, errInvalidRequest, fmt.Sprintf("At most %d appids are allowed", maxPriceAppIDs))
+		return
+	}
+
+	prices, err := h.fetchPrices(appIDs, cc)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch prices")
+		return
+	}
+
+	result := make([]StorePrice, 0, len(appIDs))
+	for _, appID := range appIDs {
+		if price, ok := prices[appID]; ok {
+			result = append(result, price)
+		}
+	}
+
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+func (h *SteamHandlers) HandleLibraryPrices(w http.ResponseWriter, r *http.Request) {
+	// 1. Get session and validate
+	steamID, err := h.steamAuth.GetSteamID(r)
+	if err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	cc := LocaleFromContext(r.Context()).Country
+
+	// 2. Get the library
+	ownedGames, err := h.fetchOwnedGames(steamID)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch owned games")
+		return
+	}
+
+	appIDs := make([]int, len(ownedGames))
+	names := make(map[int]string, len(ownedGames))
+	for i, game := range ownedGames {
+		appIDs[i] = game.AppID
+		names[game.AppID] = game.Name
+	}
+
+	// 3. Price the whole library in batches
+	prices, err := h.fetchPrices(appIDs, cc)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch prices")
+		return
+	}
+
+	result := LibraryPrices{
+		CountryCode: cc,
+		Totals:      map[string]int{},
+		Prices:      make([]StorePrice, 0, len(prices)),
+	}
+	for _, price := range prices {
+		price.Name = names[price.AppID]
+		if price.Priced {
+			result.Totals[price.Currency] += price.Final
+		}
+		result.Prices = append(result.Prices, price)
+	}
+
+	sort.SliceStable(result.Prices, func(i, j int) bool {
+		return result.Prices[i].Final > result.Prices[j].Final
+	})
+
+	// 4. Send response
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+func (h *SteamHandlers) HandleRegionalPrices(w http.ResponseWriter, r *http.Request) {
+	if h.rates == nil {
+		respondWithError(w, r, errNotReady, "Exchange rates not loaded")
+		return
+	}
+
+	appID, err := h.resolveAppID(chi.URLParam(r, "appid"))
+	if err != nil {
+		respondWithError(w, r, fmt.Errorf("%w: %v", errNotFound, err), err.Error())
+		return
+	}
+
+	target := LocaleFromContext(r.Context()).Currency
+	if !h.rates.Has(target) {
+		if r.URL.Query().Get("currency") != "" {
+			respondWithError(w, r, errInvalidRequest, "No exchange rate for currency "+target)
+			return
+		}
+		target = h.rates.Base
+	}
+
+	countries := h.regionCountries
+	if list := r.URL.Query().Get("countries"); list != "" {
+		countries = strings.Split(list, ",")
+		if len(countries) > maxRegionalCountries {
+			respondWithError(w, r, errInvalidRequest, fmt.Sprintf("At most %d countries are allowed", maxRegionalCountries))
+			return
+		}
+	}
+
+	// 1. Price the game in every storefront
+	result := RegionalPrices{
+		AppID:       appID,
+		Currency:    target,
+		RatesDate:   h.rates.Updated,
+		Regions:     []RegionalPrice{},
+		Unavailable: []string{},
+	}
+	for _, country := range countries {
+		cc, err := validCountryCode(country)
+		if err != nil {
+			respondWithError(w, r, errInvalidRequest, err.Error())
+			return
+		}
+
+		prices, err := h.fetchPrices([]int{appID}, cc)
+		if errors.Is(err, errRateLimited) {
+			respondWithError(w, r, err, "Steam rate limit reached, retry later")
+			return
+		}
+		if err != nil {
+			log.Printf("Error fetching price for %s: %v", cc, err)
+			result.Unavailable = append(result.Unavailable, cc)
+			continue
+		}
+
+		price, ok := prices[appID]
+		if !ok || !price.Priced {
+			result.Unavailable = append(result.Unavailable, cc)
+			continue
+		}
+
+		// 2. Convert to the requested currency
+		convertedInitial, err := h.rates.Convert(price.Initial, price.Currency, target)
+		if err != nil {
+			log.Printf("Error converting price for %s: %v", cc, err)
+			result.Unavailable = append(result.Unavailable, cc)
+			continue
+		}
+		convertedFinal, _ := h.rates.Convert(price.Final, price.Currency, target)
+
+		result.Regions = append(result.Regions, RegionalPrice{
+			CountryCode:      cc,
+			Currency:         price.Currency,
+			Initial:          price.Initial,
+			Final:            price.Final,
+			DiscountPercent:  price.DiscountPercent,
+			FinalFormatted:   price.FinalFormatted,
+			ConvertedInitial: convertedInitial,
+			ConvertedFinal:   convertedFinal,
+		})
+	}
+
+	// 3. Cheapest first, compared against the cheapest region
+	sort.SliceStable(result.Regions, func(i, j int) bool {
+		return result.Regions[i].ConvertedFinal < result.Regions[j].ConvertedFinal
+	})
+	if n := len(result.Regions); n > 0 {
+		cheapest := result.Regions[0].ConvertedFinal
+		for i := range result.Regions {
+			if cheapest > 0 {
+				result.Regions[i].OverCheapestPercent = float64(result.Regions[i].ConvertedFinal-cheapest) * 100 / float64(cheapest)
+			}
+		}
+		result.Cheapest = &result.Regions[0]
+		result.MostExpensive = &result.Regions[n-1]
+	}
+
+	if app, ok := h.client.Catalog.Lookup(appID); ok {
+		result.Name = app.Name
+	}
+
+	// 4. Send response
+	respondWithJSON(w, http.StatusOK, result)
+}
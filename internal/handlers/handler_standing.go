@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+// Accounts younger than this are flagged in the standing report
+const newAccountAge = 30 * 24 * time.Hour
+
+func (h *SteamHandlers) HandlePlayerStanding(w http.ResponseWriter, r *http.Request) {
+	// 1. Get session and validate
+	if _, err := h.steamAuth.GetSteamID(r); err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	steamID := chi.URLParam(r, "steamid")
+	if _, err := strconv.ParseUint(steamID, 10, 64); err != nil {
+		respondWithError(w, r, errInvalidRequest, fmt.Sprintf("Invalid SteamID: %q", steamID))
+		return
+	}
+
+	// 2. Build the report
+	reports, err := h.fetchStandingReports([]string{steamID})
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch standing report")
+		return
+	}
+
+	report, ok := reports[steamID]
+	if !ok {
+		respondWithError(w, r, errNotFound, "Player not found")
+		return
+	}
+
+	// 3. Send response
+	respondWithJSON(w, http.StatusOK, report)
+}
+
+func (h *SteamHandlers) HandlePlayerStandings(w http.ResponseWriter, r *http.Request) {
+	// 1. Get session and validate
+	if _, err := h.steamAuth.GetSteamID(r); err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	steamIDs := []string{}
+	seen := map[string]bool{}
+	for _, id := range strings.Split(r.URL.Query().Get("steamids"), ",") {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		if _, err := strconv.ParseUint(id, 10, 64); err != nil {
+			respondWithError(w, r, errInvalidRequest, fmt.Sprintf("Invalid SteamID: %q", id))
+			return
+		}
+		seen[id] = true
+		steamIDs = append(steamIDs, id)
+	}
+	if len(steamIDs) == 0 || len(steamIDs) > steamIDBatchSize {
+		respondWithError(w, r, errInvalidRequest, fmt.Sprintf("Between 1 and %d SteamIDs are required", steamIDBatchSize))
+		return
+	}
+
+	// 2. Build the reports
+	reports, err := h.fetchStandingReports(steamIDs)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to fetch standing reports")
+		return
+	}
+
+	result := make([]StandingReport, 0, len(steamIDs))
+	for _, id := range steamIDs {
+		if report, ok := reports[id]; ok {
+			result = append(result, report)
+		}
+	}
+
+	// 3. Send response
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+// fetchStandingReports returns the reports of the given players, reusing the
+// ones still in the cache and building the rest with one bans call and one
+// summaries call per batch.
+func (h *SteamHandlers) fetchStandingReports(steamIDs []string) (map[string]StandingReport, error) {
+	reports := make(map[string]StandingReport, len(steamIDs))
+
+	missing := []string{}
+	for _, id := range steamIDs {
+		if val, ok := h.client.Cache.Get(standingCacheKey(id)); ok {
+			var report StandingReport
+			if err := json.Unmarshal(val, &report); err == nil {
+				reports[id] = report
+				continue
+			}
+		}
+		missing = append(missing, id)
+	}
+	if len(missing) == 0 {
+		return reports, nil
+	}
+
+	bans, err := h.fetchPlayerBans(missing)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := h.fetchPlayerSummaries(missing)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	for _, id := range missing {
+		ban, ok := bans[id]
+		if !ok {
+			continue
+		}
+
+		report := newStandingReport(ban, now)
+		report.addSummary(summaries[id], now)
+		if report.PublicProfile {
+			level, err := h.fetchSteamLevel(id)
+			if err != nil {
+				log.Printf("Error fetching steam level of %s: %v", id, err)
+			} else {
+				report.SteamLevel = &level
+			}
+		}
+
+		if data, err := json.Marshal(report); err == nil {
+			h.client.Cache.Add(standingCacheKey(id), data)
+		}
+		reports[id] = report
+	}
+
+	return reports, nil
+}
+
+func (h *SteamHandlers) fetchPlayerBans(steamIDs []string) (map[string]steam.PlayerBan, error) {
+	bans := make(map[string]steam.PlayerBan, len(steamIDs))
+	for start := 0; start < len(steamIDs); start += steamIDBatchSize {
+		end := min(start+steamIDBatchSize, len(steamIDs))
+
+		url := fmt.Sprintf("https://api.steampowered.com/ISteamUser/GetPlayerBans/v1/?key=%s&steamids=%s",
+			h.steamAuth.GetAPIKey(), strings.Join(steamIDs[start:end], ","))
+
+		bodyBytes, err := h.getResponseBody(url)
+		if err != nil {
+			return nil, err
+		}
+
+		var bansResponse steam.PlayerBansResponse
+		if err := decodeSteam(bodyBytes, &bansResponse); err != nil {
+			return nil, fmt.Errorf("decoding player bans: %w", err)
+		}
+
+		for _, ban := range bansResponse.Players {
+			bans[ban.SteamID] = ban
+		}
+	}
+
+	return bans, nil
+}
+
+func newStandingReport(ban steam.PlayerBan, checkedAt time.Time) StandingReport {
+	report := StandingReport{
+		SteamID:          ban.SteamID,
+		VACBanned:        ban.VACBanned,
+		NumberOfVACBans:  ban.NumberOfVACBans,
+		NumberOfGameBans: ban.NumberOfGameBans,
+		CommunityBanned:  ban.CommunityBanned,
+		EconomyBan:       ban.EconomyBan,
+		DaysSinceLastBan: ban.DaysSinceLastBan,
+		Flags:            []string{},
+		CheckedAt:        checkedAt,
+	}
+
+	if ban.VACBanned {
+		report.Flags = append(report.Flags, "vac_banned")
+	}
+	if ban.NumberOfGameBans > 0 {
+		report.Flags = append(report.Flags, "game_banned")
+	}
+	if ban.CommunityBanned {
+		report.Flags = append(report.Flags, "community_banned")
+	}
+	switch ban.EconomyBan {
+	case "banned":
+		report.Flags = append(report.Flags, "trade_banned")
+	case "probation":
+		report.Flags = append(report.Flags, "trade_probation")
+	}
+
+	return report
+}
+
+// addSummary adds the profile of the player to the report, flagging
+// private profiles and young accounts. A missing summary leaves the profile
+// private.
+func (report *StandingReport) addSummary(summary steam.Player, now time.Time) {
+	report.PersonaName = summary.Name
+	report.ProfileURL = summary.ProfileURL
+	report.PublicProfile = summary.Public
+	if !summary.Created.IsZero() {
+		created := summary.Created
+		age := int(now.Sub(created).Hours() / 24)
+		report.AccountCreated = &created
+		report.AccountAgeDays = &age
+		if now.Sub(created) < newAccountAge {
+			report.Flags = append(report.Flags, "new_account")
+		}
+	}
+	if !report.PublicProfile {
+		report.Flags = append(report.Flags, "private_profile")
+	}
+}
+
+func standingCacheKey(steamID string) string {
+	return "standing:" + steamID
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+func TestNewStandingReportFlags(t *testing.T) {
+	cases := []struct {
+		name string
+		ban  steam.PlayerBan
+		want []string
+	}{
+		{"clean", steam.PlayerBan{EconomyBan: "none"}, []string{}},
+		{"vac", steam.PlayerBan{VACBanned: true, NumberOfVACBans: 1}, []string{"vac_banned"}},
+		{"game", steam.PlayerBan{NumberOfGameBans: 2}, []string{"game_banned"}},
+		{"community", steam.PlayerBan{CommunityBanned: true}, []string{"community_banned"}},
+		{"trade banned", steam.PlayerBan{EconomyBan: "banned"}, []string{"trade_banned"}},
+		{"trade probation", steam.PlayerBan{EconomyBan: "probation"}, []string{"trade_probation"}},
+		{"everything", steam.PlayerBan{VACBanned: true, NumberOfGameBans: 1, CommunityBanned: true, EconomyBan: "banned"},
+			[]string{"vac_banned", "game_banned", "community_banned", "trade_banned"}},
+	}
+	for _, c := range cases {
+		report := newStandingReport(c.ban, time.Now())
+		if !slices.Equal(report.Flags, c.want) {
+			t.Errorf("%s: expected flags %v, got %v", c.name, c.want, report.Flags)
+		}
+	}
+}
+
+func TestStandingReportAddSummary(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name    string
+		summary steam.Player
+		ageDays *int
+		want    []string
+	}{
+		{"old public account", steam.Player{Public: true, Created: now.AddDate(-2, 0, 0)}, intPtr(731), []string{}},
+		{"new account", steam.Player{Public: true, Created: now.Add(-10 * 24 * time.Hour)}, intPtr(10), []string{"new_account"}},
+		{"private profile", steam.Player{Created: now.AddDate(-1, 0, 0)}, intPtr(365), []string{"private_profile"}},
+		{"hidden creation date", steam.Player{}, nil, []string{"private_profile"}},
+	}
+	for _, c := range cases {
+		report := newStandingReport(steam.PlayerBan{}, now)
+		report.addSummary(c.summary, now)
+		if !slices.Equal(report.Flags, c.want) {
+			t.Errorf("%s: expected flags %v, got %v", c.name, c.want, report.Flags)
+		}
+		switch {
+		case c.ageDays == nil && report.AccountAgeDays != nil:
+			t.Errorf("%s: expected no account age, got %d", c.name, *report.AccountAgeDays)
+		case c.ageDays != nil && (report.AccountAgeDays == nil || *report.AccountAgeDays != *c.ageDays):
+			t.Errorf("%s: expected an account age of %d days, got %v", c.name, *c.ageDays, report.AccountAgeDays)
+		}
+	}
+}
+
+func TestFetchStandingReportsCached(t *testing.T) {
+	h := newReplayHandlers(t)
+
+	reports, err := h.fetchStandingReports([]string{testSteamID, testFriendID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mine := reports[testSteamID]
+	if mine.PersonaName != "Rabscuttle" || mine.SteamLevel == nil || *mine.SteamLevel != 12 || len(mine.Flags) != 0 {
+		t.Errorf("unexpected report %+v", mine)
+	}
+	created := time.Unix(1262304000, 0).UTC()
+	if mine.AccountCreated == nil || !mine.AccountCreated.Equal(created) || mine.AccountAgeDays == nil || *mine.AccountAgeDays < 5000 {
+		t.Errorf("expected the account age, got %v and %v", mine.AccountCreated, mine.AccountAgeDays)
+	}
+
+	// The level of a private profile isn't asked for
+	friend := reports[testFriendID]
+	want := []string{"vac_banned", "game_banned", "community_banned", "trade_probation", "private_profile"}
+	if !slices.Equal(friend.Flags, want) || friend.SteamLevel != nil {
+		t.Errorf("expected flags %v and no level, got %+v", want, friend)
+	}
+
+	requests := h.client.Metrics.Snapshot()["api.steampowered.com"].Requests
+	if requests != 3 {
+		t.Errorf("expected bans, summaries and one level request, got %d", requests)
+	}
+
+	// Both reports are cached now, even when asked for alone
+	for _, ids := range [][]string{{testSteamID, testFriendID}, {testFriendID}} {
+		reports, err := h.fetchStandingReports(ids)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(reports) != len(ids) || !slices.Equal(reports[testFriendID].Flags, want) {
+			t.Errorf("expected the cached reports of %v, got %+v", ids, reports)
+		}
+	}
+	if got := h.client.Metrics.Snapshot()["api.steampowered.com"].Requests; got != requests {
+		t.Errorf("expected no new upstream request, got %d", got-requests)
+	}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
@@ -1,327 +1,468 @@
 package handlers
 
-type SteamInventoryResponse struct {
-	Assets              []Asset       `json:"assets"`
-	Descriptions        []Description `json:"descriptions"`
-	MoreItems           int           `json:"more_items"`
-	LastAssetid         string        `json:"last_assetid"`
-	TotalInventoryCount int           `json:"total_inventory_count"`
-	Success             int           `json:"success"`
-	Rwgrsn              int           `json:"rwgrsn"`
-}
-
-type Asset struct {
-	AppID      int    `json:"appid"`
-	ContextID  string `json:"contextid"`
-	AssetID    string `json:"assetid"`
-	ClassID    string `json:"classid"`
-	InstanceID string `json:"instanceid"`
-	Amount     string `json:"amount"`
-}
-
-type Description struct {
-	Appid                       int                `json:"appid"`
-	Classid                     string             `json:"classid"`
-	Instanceid                  string             `json:"instanceid"`
-	Currency                    int                `json:"currency"`
-	BackgroundColor             string             `json:"background_color"`
-	IconURL                     string             `json:"icon_url"`
-	IconURLLarge                string             `json:"icon_url_large"`
-	Descriptions                []DescriptionValue `json:"descriptions"`
-	Tradable                    int                `json:"tradable"`
-	Name                        string             `json:"name"`
-	Type                        string             `json:"type"`
-	MarketName                  string             `json:"market_name"`
-	MarketHashName              string             `json:"market_hash_name"`
-	MarketFeeApp                int                `json:"market_fee_app"`
-	Commodity                   int                `json:"commodity"`
-	MarketTradableRestriction   int                `json:"market_tradable_restriction"`
-	MarketMarketableRestriction int                `json:"market_marketable_restriction"`
-	Marketable                  int                `json:"marketable"`
-	Tags                        []Tag              `json:"tags"`
-}
-
-type DescriptionValue struct {
-	Value string `json:"value"`
-}
-
-type Tag struct {
-	Category              string `json:"category"`
-	InternalName          string `json:"internal_name"`
-	LocalizedCategoryName string `json:"localized_category_name"`
-	LocalizedTagName      string `json:"localized_tag_name"`
-}
-
-type SteamTradeResponse struct {
-	Response struct {
-		Items []struct {
-			AppID      int    `json:"appid"`
-			ContextID  string `json:"contextid"`
-			AssetID    string `json:"assetid"`
-			ClassID    string `json:"classid"`
-			InstanceID string `json:"instanceid"`
-			Amount     string `json:"amount"`
-			Desc       struct {
-				Type       string `json:"type"`
-				Name       string `json:"name"`
-				MarketName string `json:"market_name"`
-				MarketHash string `json:"market_hash_name"`
-				IconURL    string `json:"icon_url"`
-				Tradable   int    `json:"tradable"`
-				Marketable int    `json:"marketable"`
-			} `json:"descriptions"`
-		} `json:"items"`
-		TotalInventoryCount int `json:"total_inventory_count"`
-	} `json:"response"`
-}
-
-type GameData struct {
-	Success bool `json:"success"`
-	Data    struct {
-		Type                string `json:"type"`
-		Name                string `json:"name"`
-		SteamAppid          int    `json:"steam_appid"`
-		IsFree              bool   `json:"is_free"`
-		ControllerSupport   string `json:"controller_support"`
-		Dlc                 []int  `json:"dlc"`
-		DetailedDescription string `json:"detailed_description"`
-		AboutTheGame        string `json:"about_the_game"`
-		ShortDescription    string `json:"short_description"`
-		SupportedLanguages  string `json:"supported_languages"`
-		PriceOverview       struct {
-			Currency         string `json:"currency"`
-			Initial          int    `json:"initial"`
-			Final            int    `json:"final"`
-			DiscountPercent  int    `json:"discount_percent"`
-			InitialFormatted string `json:"initial_formatted"`
-			FinalFormatted   string `json:"final_formatted"`
-		} `json:"price_overview"`
-		Categories []struct {
-			ID          int    `json:"id"`
-			Description string `json:"description"`
-		} `json:"categories"`
-		Genres []struct {
-			ID          string `json:"id"`
-			Description string `json:"description"`
-		} `json:"genres"`
-		ReleaseDate struct {
-			ComingSoon bool   `json:"coming_soon"`
-			Date       string `json:"date"`
-		} `json:"release_date"`
-	} `json:"data"`
-}
-
-type GameFromList struct {
-	AppID                    int    `json:"appid"`
-	Name                     string `json:"name"`
-	PlaytimeForever          int    `json:"playtime_forever"`
-	ImgIconURL               string `json:"img_icon_url"`
-	HasCommunityVisibleStats bool   `json:"has_community_visible_stats,omitempty"`
-	PlaytimeWindowsForever   int    `json:"playtime_windows_forever"`
-	PlaytimeMacForever       int    `json:"playtime_mac_forever"`
-	PlaytimeLinuxForever     int    `json:"playtime_linux_forever"`
-	PlaytimeDeckForever      int    `json:"playtime_deck_forever"`
-	RtimeLastPlayed          int    `json:"rtime_last_played"`
-	CapsuleFilename          string `json:"capsule_filename"`
-	HasWorkshop              bool   `json:"has_workshop"`
-	HasMarket                bool   `json:"has_market"`
-	HasDlc                   bool   `json:"has_dlc"`
-	ContentDescriptorids     []int  `json:"content_descriptorids,omitempty"`
-	PlaytimeDisconnected     int    `json:"playtime_disconnected"`
-	HasLeaderboards          bool   `json:"has_leaderboards,omitempty"`
-	SortAs                   string `json:"sort_as,omitempty"`
-	Playtime2Weeks           int    `json:"playtime_2weeks,omitempty"`
-}
-
-type GameDataFull struct {
-	Success bool `json:"success"`
-	Data    struct {
-		Type                string `json:"type"`
-		Name                string `json:"name"`
-		SteamAppid          int    `json:"steam_appid"`
-		RequiredAge         int    `json:"required_age"`
-		IsFree              bool   `json:"is_free"`
-		ControllerSupport   string `json:"controller_support"`
-		Dlc                 []int  `json:"dlc"`
-		DetailedDescription string `json:"detailed_description"`
-		AboutTheGame        string `json:"about_the_game"`
-		ShortDescription    string `json:"short_description"`
-		SupportedLanguages  string `json:"supported_languages"`
-		HeaderImage         string `json:"header_image"`
-		CapsuleImage        string `json:"capsule_image"`
-		CapsuleImagev5      string `json:"capsule_imagev5"`
-		Website             string `json:"website"`
-		PcRequirements      struct {
-			Minimum     string `json:"minimum"`
-			Recommended string `json:"recommended"`
-		} `json:"pc_requirements"`
-		MacRequirements struct {
-			Minimum     string `json:"minimum"`
-			Recommended string `json:"recommended"`
-		} `json:"mac_requirements"`
-		LinuxRequirements struct {
-			Minimum     string `json:"minimum"`
-			Recommended string `json:"recommended"`
-		} `json:"linux_requirements"`
-		LegalNotice   string   `json:"legal_notice"`
-		Developers    []string `json:"developers"`
-		Publishers    []string `json:"publishers"`
-		PriceOverview struct {
-			Currency         string `json:"currency"`
-			Initial          int    `json:"initial"`
-			Final            int    `json:"final"`
-			DiscountPercent  int    `json:"discount_percent"`
-			InitialFormatted string `json:"initial_formatted"`
-			FinalFormatted   string `json:"final_formatted"`
-		} `json:"price_overview"`
-		Packages      []int `json:"packages"`
-		PackageGroups []struct {
-			Name                    string `json:"name"`
-			Title                   string `json:"title"`
-			Description             string `json:"description"`
-			SelectionText           string `json:"selection_text"`
-			SaveText                string `json:"save_text"`
-			DisplayType             int    `json:"display_type"`
-			IsRecurringSubscription string `json:"is_recurring_subscription"`
-			Subs                    []struct {
-				Packageid                int    `json:"packageid"`
-				PercentSavingsText       string `json:"percent_savings_text"`
-				PercentSavings           int    `json:"percent_savings"`
-				OptionText               string `json:"option_text"`
-				OptionDescription        string `json:"option_description"`
-				CanGetFreeLicense        string `json:"can_get_free_license"`
-				IsFreeLicense            bool   `json:"is_free_license"`
-				PriceInCentsWithDiscount int    `json:"price_in_cents_with_discount"`
-			} `json:"subs"`
-		} `json:"package_groups"`
-		Platforms struct {
-			Windows bool `json:"windows"`
-			Mac     bool `json:"mac"`
-			Linux   bool `json:"linux"`
-		} `json:"platforms"`
-		Categories []struct {
-			ID          int    `json:"id"`
-			Description string `json:"description"`
-		} `json:"categories"`
-		Genres []struct {
-			ID          string `json:"id"`
-			Description string `json:"description"`
-		} `json:"genres"`
-		Screenshots []struct {
-			ID            int    `json:"id"`
-			PathThumbnail string `json:"path_thumbnail"`
-			PathFull      string `json:"path_full"`
-		} `json:"screenshots"`
-		Movies []struct {
-			ID        int    `json:"id"`
-			Name      string `json:"name"`
-			Thumbnail string `json:"thumbnail"`
-			Webm      struct {
-				Num480 string `json:"480"`
-				Max    string `json:"max"`
-			} `json:"webm"`
-			Mp4 struct {
-				Num480 string `json:"480"`
-				Max    string `json:"max"`
-			} `json:"mp4"`
-			Highlight bool `json:"highlight"`
-		} `json:"movies"`
-		Recommendations struct {
-			Total int `json:"total"`
-		} `json:"recommendations"`
-		Achievements struct {
-			Total       int `json:"total"`
-			Highlighted []struct {
-				Name string `json:"name"`
-				Path string `json:"path"`
-			} `json:"highlighted"`
-		} `json:"achievements"`
-		ReleaseDate struct {
-			ComingSoon bool   `json:"coming_soon"`
-			Date       string `json:"date"`
-		} `json:"release_date"`
-		SupportInfo struct {
-			URL   string `json:"url"`
-			Email string `json:"email"`
-		} `json:"support_info"`
-		Background         string `json:"background"`
-		BackgroundRaw      string `json:"background_raw"`
-		ContentDescriptors struct {
-			Ids   []int  `json:"ids"`
-			Notes string `json:"notes"`
-		} `json:"content_descriptors"`
-		Ratings struct {
-			Esrb struct {
-				Rating      string `json:"rating"`
-				Descriptors string `json:"descriptors"`
-			} `json:"esrb"`
-			Oflc struct {
-				Rating      string `json:"rating"`
-				Descriptors string `json:"descriptors"`
-			} `json:"oflc"`
-			Nzoflc struct {
-				Rating      string `json:"rating"`
-				Descriptors string `json:"descriptors"`
-			} `json:"nzoflc"`
-			Cero struct {
-				Rating      string `json:"rating"`
-				Descriptors string `json:"descriptors"`
-			} `json:"cero"`
-			Pegi struct {
-				Rating      string `json:"rating"`
-				Descriptors string `json:"descriptors"`
-			} `json:"pegi"`
-			Usk struct {
-				Rating      string `json:"rating"`
-				RatingID    string `json:"rating_id"`
-				Descriptors string `json:"descriptors"`
-			} `json:"usk"`
-			Kgrb struct {
-				Rating      string `json:"rating"`
-				Descriptors string `json:"descriptors"`
-			} `json:"kgrb"`
-			Dejus struct {
-				Rating      string `json:"rating"`
-				Descriptors string `json:"descriptors"`
-			} `json:"dejus"`
-			Fpb struct {
-				Rating string `json:"rating"`
-			} `json:"fpb"`
-			Csrr struct {
-				Rating      string `json:"rating"`
-				Descriptors string `json:"descriptors"`
-			} `json:"csrr"`
-			SteamGermany struct {
-				RatingGenerated string `json:"rating_generated"`
-				Rating          string `json:"rating"`
-				RequiredAge     string `json:"required_age"`
-				Banned          string `json:"banned"`
-				UseAgeGate      string `json:"use_age_gate"`
-				Descriptors     string `json:"descriptors"`
-			} `json:"steam_germany"`
-		} `json:"ratings"`
-	} `json:"data"`
-}
-
-type PlayerResponse struct {
-	Response struct {
-		Players []struct {
-			Steamid                  string `json:"steamid"`
-			Communityvisibilitystate int    `json:"communityvisibilitystate"`
-			Profilestate             int    `json:"profilestate"`
-			Personaname              string `json:"personaname"`
-			Commentpermission        int    `json:"commentpermission"`
-			Profileurl               string `json:"profileurl"`
-			Avatar                   string `json:"avatar"`
-			Avatarmedium             string `json:"avatarmedium"`
-			Avatarfull               string `json:"avatarfull"`
-			Avatarhash               string `json:"avatarhash"`
-			Lastlogoff               int    `json:"lastlogoff"`
-			Personastate             int    `json:"personastate"`
-			Realname                 string `json:"realname"`
-			Primaryclanid            string `json:"primaryclanid"`
-			Timecreated              int    `json:"timecreated"`
-			Personastateflags        int    `json:"personastateflags"`
-		} `json:"players"`
-	} `json:"response"`
+import (
+	"time"
+
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+// InventoryItem is an inventory item with the tags of its game mapped onto
+// common fields.
+type InventoryItem struct {
+	steam.InventoryItem
+	Category string `json:"category,omitempty"`
+	Rarity   string `json:"rarity,omitempty"`
+	Quality  string `json:"quality,omitempty"`
+	Exterior string `json:"exterior,omitempty"`
+	Game     string `json:"game,omitempty"`
+	UsedBy   string `json:"used_by,omitempty"`
+}
+
+type InventoryResult struct {
+	SteamID             string          `json:"steamid"`
+	AppID               string          `json:"appid"`
+	ContextID           string          `json:"contextid"`
+	TotalInventoryCount int             `json:"total_inventory_count"`
+	Items               []InventoryItem `json:"items"`
+}
+
+type AllInventoriesResult struct {
+	SteamID     string            `json:"steamid"`
+	TotalItems  int               `json:"total_items"`
+	Inventories []InventoryResult `json:"inventories"`
+}
+
+// InventoryValuation amounts are in minor units of Currency. Values are what
+// buyers pay at the lowest listing, the after fees ones what selling would
+// leave once Steam and the publisher take their cut.
+type InventoryValuation struct {
+	SteamID        string          `json:"steamid"`
+	AppID          string          `json:"appid"`
+	ContextID      string          `json:"contextid"`
+	Currency       string          `json:"currency"`
+	Complete       bool            `json:"complete"`    // False while some items wait to be priced
+	TotalItems     int             `json:"total_items"` // Marketable items, counting every unit of a stack
+	PricedItems    int             `json:"priced_items"`
+	Value          int             `json:"value"`
+	ValueAfterFees int             `json:"value_after_fees"`
+	OnHoldValue    int             `json:"on_hold_value"` // Part of the value that can't be sold yet
+	Items          []ItemValuation `json:"items"`
+}
+
+type ItemValuation struct {
+	AppID          int    `json:"appid"`
+	MarketHashName string `json:"market_hash_name"`
+	Name           string `json:"name"`
+	Count          int    `json:"count"`
+	OnHold         int    `json:"on_hold"` // Units under a market trade hold
+	TradeHoldDays  int    `json:"trade_hold_days,omitempty"`
+	Price          *int   `json:"price"` // Lowest listing, or the median price without listings
+	PriceAfterFees *int   `json:"price_after_fees"`
+	Value          int    `json:"value"`
+	ValueAfterFees int    `json:"value_after_fees"`
+	Pending        bool   `json:"pending,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+type Portfolio struct {
+	SteamID   string              `json:"steamid"`
+	AppID     string              `json:"appid"`
+	ContextID string              `json:"contextid"`
+	Currency  string              `json:"currency"`
+	Snapshots []PortfolioSnapshot `json:"snapshots"`
+}
+
+// PortfolioSnapshot amounts are in minor units of the portfolio currency.
+type PortfolioSnapshot struct {
+	Time           time.Time       `json:"time"`
+	Complete       bool            `json:"complete"` // False while some items wait for their first price
+	Value          int             `json:"value"`
+	ValueAfterFees int             `json:"value_after_fees"`
+	Items          []PortfolioItem `json:"items"`
+}
+
+type PortfolioItem struct {
+	AppID          int       `json:"appid"`
+	MarketHashName string    `json:"market_hash_name"`
+	Name           string    `json:"name"`
+	Count          int       `json:"count"`
+	Price          int       `json:"price"`
+	PricedAt       time.Time `json:"priced_at"`          // When the price was read, older than the snapshot when stale
+	Stale          bool      `json:"stale,omitempty"`    // Price of the previous snapshot, Steam had none this time
+	Unpriced       bool      `json:"unpriced,omitempty"` // Never priced yet, left out of the value
+}
+
+type PortfolioReport struct {
+	SteamID   string           `json:"steamid"`
+	AppID     string           `json:"appid"`
+	ContextID string           `json:"contextid"`
+	Currency  string           `json:"currency"`
+	Current   *PortfolioPoint  `json:"current"`
+	Daily     *PortfolioChange `json:"daily"`  // Nil until the history covers a day
+	Weekly    *PortfolioChange `json:"weekly"` // Nil until the history covers a week
+	Gainers   []ItemChange     `json:"gainers"`
+	Losers    []ItemChange     `json:"losers"`
+	Series    []PortfolioPoint `json:"series"`
+}
+
+type PortfolioPoint struct {
+	Time           time.Time `json:"time"`
+	Value          int       `json:"value"`
+	ValueAfterFees int       `json:"value_after_fees"`
+	Items          int       `json:"items"`
+	Complete       bool      `json:"complete"`
+}
+
+type PortfolioChange struct {
+	From          time.Time `json:"from"`
+	To            time.Time `json:"to"`
+	Before        int       `json:"before"`
+	After         int       `json:"after"`
+	Change        int       `json:"change"`
+	ChangePercent float64   `json:"change_percent"`
+}
+
+type ItemChange struct {
+	AppID          int     `json:"appid"`
+	MarketHashName string  `json:"market_hash_name"`
+	Name           string  `json:"name"`
+	Count          int     `json:"count"`
+	PriceBefore    int     `json:"price_before"`
+	Price          int     `json:"price"`
+	Change         int     `json:"change"` // Of the value of the items held
+	ChangePercent  float64 `json:"change_percent"`
+}
+
+type InventoryApp struct {
+	AppID      int                `json:"appid"`
+	Name       string             `json:"name"`
+	Icon       string             `json:"icon"`
+	AssetCount int                `json:"asset_count"`
+	Contexts   []InventoryContext `json:"contexts"`
+}
+
+type InventoryContext struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	AssetCount int    `json:"asset_count"`
+}
+
+type StorePrice struct {
+	AppID            int    `json:"appid"`
+	Name             string `json:"name,omitempty"`
+	CountryCode      string `json:"cc"`
+	Priced           bool   `json:"priced"`
+	Currency         string `json:"currency,omitempty"`
+	Initial          int    `json:"initial"`
+	Final            int    `json:"final"`
+	DiscountPercent  int    `json:"discount_percent"`
+	Savings          int    `json:"savings"`
+	InitialFormatted string `json:"initial_formatted,omitempty"`
+	FinalFormatted   string `json:"final_formatted,omitempty"`
+}
+
+type LibraryPrices struct {
+	CountryCode string         `json:"cc"`
+	Totals      map[string]int `json:"totals"`
+	Prices      []StorePrice   `json:"prices"`
+}
+
+type RegionalPrices struct {
+	AppID         int             `json:"appid"`
+	Name          string          `json:"name,omitempty"`
+	Currency      string          `json:"currency"`
+	RatesDate     string          `json:"rates_date,omitempty"`
+	Cheapest      *RegionalPrice  `json:"cheapest"`
+	MostExpensive *RegionalPrice  `json:"most_expensive"`
+	Regions       []RegionalPrice `json:"regions"`
+	Unavailable   []string        `json:"unavailable"`
+}
+
+type RegionalPrice struct {
+	CountryCode         string  `json:"cc"`
+	Currency            string  `json:"currency"`
+	Initial             int     `json:"initial"`
+	Final               int     `json:"final"`
+	DiscountPercent     int     `json:"discount_percent"`
+	FinalFormatted      string  `json:"final_formatted"`
+	ConvertedInitial    int     `json:"converted_initial"`
+	ConvertedFinal      int     `json:"converted_final"`
+	OverCheapestPercent float64 `json:"over_cheapest_percent"`
+}
+
+type UserPrefs struct {
+	Language string `json:"language,omitempty"`
+	Country  string `json:"country,omitempty"`
+	Currency string `json:"currency,omitempty"`
+}
+
+type Wishlist struct {
+	SteamID     string          `json:"steamid"`
+	CountryCode string          `json:"cc"`
+	ImportedAt  time.Time       `json:"imported_at"`
+	Items       []WishlistItem  `json:"items"`
+	Alerts      []WishlistAlert `json:"alerts"`
+}
+
+type WishlistItem struct {
+	AppID       int         `json:"appid"`
+	Name        string      `json:"name"`
+	Priority    int         `json:"priority"`
+	DateAdded   time.Time   `json:"date_added"`
+	TargetPrice int         `json:"target_price,omitempty"`
+	Price       *StorePrice `json:"price,omitempty"`
+	ComingSoon  bool        `json:"coming_soon"`
+	ReleaseDate string      `json:"release_date,omitempty"`
+	LastChecked time.Time   `json:"last_checked"`
+}
+
+type WishlistEntry struct {
+	WishlistItem
+	OnSale          bool        `json:"on_sale"`
+	BelowTarget     bool        `json:"below_target"`
+	Released        bool        `json:"released"`
+	AtHistoricalLow bool        `json:"at_historical_low"`
+	HistoricalLow   *PricePoint `json:"historical_low,omitempty"`
+}
+
+type WishlistAlert struct {
+	AppID int       `json:"appid"`
+	Name  string    `json:"name"`
+	Kind  string    `json:"kind"`
+	Time  time.Time `json:"time"`
+}
+
+type PricePoint struct {
+	Time            time.Time `json:"time"`
+	Currency        string    `json:"currency"`
+	Initial         int       `json:"initial"`
+	Final           int       `json:"final"`
+	DiscountPercent int       `json:"discount_percent"`
+}
+
+type LibraryGame struct {
+	AppID           int        `json:"appid"`
+	Name            string     `json:"name"`
+	PlaytimeForever int        `json:"playtime_forever"`
+	Playtime2Weeks  int        `json:"playtime_2weeks"`
+	LastPlayed      *time.Time `json:"last_played"`
+	ImgIconURL      string     `json:"img_icon_url"`
+	CurrentPlayers  *int       `json:"current_players,omitempty"`
+}
+
+type AchievementProgress struct {
+	AppID             int                 `json:"appid"`
+	GameName          string              `json:"game_name"`
+	Total             int                 `json:"total"`
+	Unlocked          int                 `json:"unlocked"`
+	CompletionPercent float64             `json:"completion_percent"`
+	Achievements      []AchievementStatus `json:"achievements"`
+}
+
+type AchievementStatus struct {
+	APIName       string     `json:"apiname"`
+	Name          string     `json:"name"`
+	Description   string     `json:"description"`
+	Icon          string     `json:"icon"`
+	Hidden        bool       `json:"hidden"`
+	Achieved      bool       `json:"achieved"`
+	UnlockTime    *time.Time `json:"unlock_time,omitempty"`
+	GlobalPercent float64    `json:"global_percent"`
+}
+
+type AchievementSummary struct {
+	GamesWithAchievements int                    `json:"games_with_achievements"`
+	PerfectGames          int                    `json:"perfect_games"`
+	Total                 int                    `json:"total"`
+	Unlocked              int                    `json:"unlocked"`
+	CompletionPercent     float64                `json:"completion_percent"`
+	AverageGameCompletion float64                `json:"average_game_completion"`
+	Games                 []GameAchievementTotal `json:"games"`
+}
+
+type GameAchievementTotal struct {
+	AppID             int     `json:"appid"`
+	Name              string  `json:"name"`
+	Total             int     `json:"total"`
+	Unlocked          int     `json:"unlocked"`
+	CompletionPercent float64 `json:"completion_percent"`
+}
+
+type BadgeOverview struct {
+	Level                int                    `json:"level"`
+	XP                   int                    `json:"xp"`
+	XPNeededToLevelUp    int                    `json:"xp_needed_to_level_up"`
+	XPNeededCurrentLevel int                    `json:"xp_needed_current_level"`
+	CommunityBadge       CommunityBadgeProgress `json:"community_badge"`
+	GameBadges           []GameBadgeProgress    `json:"game_badges"`
+}
+
+type CommunityBadgeProgress struct {
+	Completed int `json:"completed"`
+	Total     int `json:"total"`
+}
+
+type GameBadgeProgress struct {
+	AppID              int         `json:"appid"`
+	Game               string      `json:"game,omitempty"`
+	BadgeLevel         int         `json:"badge_level"`
+	TotalCards         int         `json:"total_cards"`
+	CardsHeld          []CardCount `json:"cards_held"`
+	CardsMissing       []string    `json:"cards_missing"`
+	CardDropsRemaining *int        `json:"card_drops_remaining"`
+}
+
+type CardCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type StandingReport struct {
+	SteamID          string     `json:"steamid"`
+	PersonaName      string     `json:"personaname"`
+	ProfileURL       string     `json:"profileurl"`
+	PublicProfile    bool       `json:"public_profile"`
+	AccountCreated   *time.Time `json:"account_created"`
+	AccountAgeDays   *int       `json:"account_age_days"`
+	SteamLevel       *int       `json:"steam_level"`
+	VACBanned        bool       `json:"vac_banned"`
+	NumberOfVACBans  int        `json:"number_of_vac_bans"`
+	NumberOfGameBans int        `json:"number_of_game_bans"`
+	CommunityBanned  bool       `json:"community_banned"`
+	EconomyBan       string     `json:"economy_ban"`
+	DaysSinceLastBan int        `json:"days_since_last_ban"`
+	Flags            []string   `json:"flags"`
+	CheckedAt        time.Time  `json:"checked_at"`
+}
+
+type NewsFeed struct {
+	Page    int        `json:"page"`
+	PerPage int        `json:"per_page"`
+	Total   int        `json:"total"`
+	Items   []NewsItem `json:"items"`
+}
+
+type NewsItem struct {
+	Gid        string    `json:"gid"`
+	AppID      int       `json:"appid"`
+	GameName   string    `json:"game_name"`
+	Title      string    `json:"title"`
+	URL        string    `json:"url"`
+	Author     string    `json:"author"`
+	Contents   string    `json:"contents"`
+	FeedLabel  string    `json:"feed_label"`
+	FeedName   string    `json:"feed_name"`
+	FeedType   int       `json:"feed_type"`
+	External   bool      `json:"external"`
+	PatchNotes bool      `json:"patch_notes"`
+	Date       time.Time `json:"date"`
+}
+
+type PlayerCountSample struct {
+	Time  time.Time `json:"time"`
+	Count int       `json:"count"`
+}
+
+type PlayerCountReport struct {
+	AppID   int                 `json:"appid"`
+	Current int                 `json:"current"`
+	Peak    int                 `json:"peak"`
+	Low     int                 `json:"low"`
+	Average int                 `json:"average"`
+	Samples []PlayerCountSample `json:"samples"`
+}
+
+type Friend struct {
+	SteamID      string    `json:"steamid"`
+	FriendSince  time.Time `json:"friend_since"`
+	PersonaName  string    `json:"personaname"`
+	PersonaState int       `json:"personastate"`
+	ProfileURL   string    `json:"profileurl"`
+	Avatar       string    `json:"avatar"`
+	Playing      string    `json:"playing,omitempty"`
+}
+
+type SharedGame struct {
+	AppID            int            `json:"appid"`
+	Name             string         `json:"name"`
+	CombinedPlaytime int            `json:"combined_playtime"`
+	Playtimes        map[string]int `json:"playtimes"`
+	Categories       []string       `json:"categories"`
+}
+
+type ErrorResponse struct {
+	Error     string `json:"error"`   // Kind of failure, such as private_profile
+	Message   string `json:"message"` // What the request failed to do
+	RequestID string `json:"request_id,omitempty"`
+}
+
+type MarketHistory struct {
+	AppID          int                  `json:"appid"`
+	MarketHashName string               `json:"market_hash_name"`
+	NameID         int                  `json:"item_nameid"`
+	Currency       string               `json:"currency"`
+	History        []MarketHistoryPoint `json:"history"`
+}
+
+type MarketHistoryPoint struct {
+	Time   time.Time `json:"time"`
+	Price  int       `json:"price"` // Median sale price of the hour or day, in minor units
+	Volume int       `json:"volume"`
+}
+
+type MarketItemNameID struct {
+	AppID          int    `json:"appid"`
+	MarketHashName string `json:"market_hash_name"`
+	NameID         int    `json:"item_nameid"`
+	Error          string `json:"error,omitempty"`
+}
+
+type MarketOverview struct {
+	AppID                int    `json:"appid"`
+	MarketHashName       string `json:"market_hash_name"`
+	Currency             string `json:"currency"`
+	LowestPrice          *int   `json:"lowest_price"` // In minor units, nil without listings
+	MedianPrice          *int   `json:"median_price"` // Nil without sales in the last 24 hours
+	LowestPriceFormatted string `json:"lowest_price_formatted,omitempty"`
+	MedianPriceFormatted string `json:"median_price_formatted,omitempty"`
+	Volume               int    `json:"volume"` // Sales in the last 24 hours
+}
+
+type OrderBook struct {
+	AppID          int          `json:"appid"`
+	MarketHashName string       `json:"market_hash_name"`
+	NameID         int          `json:"item_nameid"`
+	Currency       string       `json:"currency"`
+	HighestBid     *int         `json:"highest_bid"` // In minor units, nil without buy orders
+	LowestAsk      *int         `json:"lowest_ask"`  // In minor units, nil without listings
+	Spread         *int         `json:"spread"`
+	SpreadPercent  *float64     `json:"spread_percent"`
+	BidDepth       int          `json:"bid_depth"` // Buy orders in the graph
+	AskDepth       int          `json:"ask_depth"` // Listings in the graph
+	Bids           []OrderLevel `json:"bids"`      // Best price first
+	Asks           []OrderLevel `json:"asks"`
+}
+
+type OrderLevel struct {
+	Price      int `json:"price"`      // In minor units
+	Quantity   int `json:"quantity"`   // Orders at this price
+	Cumulative int `json:"cumulative"` // Orders at this price or better
+}
+
+type MarketListings struct {
+	AppID          int             `json:"appid"`
+	MarketHashName string          `json:"market_hash_name"`
+	Currency       string          `json:"currency"`
+	Start          int             `json:"start"`
+	TotalCount     int             `json:"total_count"`
+	Listings       []MarketListing `json:"listings"`
+}
+
+type MarketListing struct {
+	ListingID      string `json:"listingid"`
+	AssetID        string `json:"assetid"`
+	Amount         int    `json:"amount"`
+	Price          int    `json:"price"`           // What the buyer pays, in minor units
+	SellerReceives int    `json:"seller_receives"` // The price without the fees
+	Fee            int    `json:"fee"`
 }
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *SteamHandlers) HandleImportWishlist(w http.ResponseWriter, r *http.Request) {
+	// 1. Get session and validate
+	steamID, err := h.steamAuth.GetSteamID(r)
+	if err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	// 2. Import it and check it in the background, prices and release
+	// dates show up once the check is done
+	if _, err := h.importWishlist(steamID); err != nil {
+		if errors.Is(err, errPrivateProfile) {
+			respondWithError(w, r, err, "Wishlist is private or empty, the stored one was kept")
+			return
+		}
+		respondWithError(w, r, err, "Failed to import wishlist")
+		return
+	}
+
+	go func() {
+		if err := h.checkWishlist(steamID); err != nil {
+			log.Printf("Error checking wishlist of %s: %v", steamID, err)
+		}
+	}()
+
+	// 3. Send response
+	h.HandleWishlist(w, r)
+}
+
+func (h *SteamHandlers) HandleWishlist(w http.ResponseWriter, r *http.Request) {
+	// 1. Get session and validate
+	steamID, err := h.steamAuth.GetSteamID(r)
+	if err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	// 2. Load the stored wishlist
+	var wishlist Wishlist
+	ok, err := h.client.Store.Get(wishlistKey(steamID), &wishlist)
+	if err != nil {
+		respondWithError(w, r, err, "Failed to read wishlist")
+		return
+	}
+	if !ok {
+		respondWithError(w, r, errNotFound, "Wishlist not imported yet")
+		return
+	}
+
+	// 3. Add the status of every game
+	filter := r.URL.Query().Get("filter")
+	entries := []WishlistEntry{}
+	for _, item := range wishlist.Items {
+		entry := WishlistEntry{WishlistItem: item}
+		for _, point := range h.priceHistory(wishlist.CountryCode, item.AppID) {
+			if entry.HistoricalLow == nil || point.Final < entry.HistoricalLow.Final {
+				low := point
+				entry.HistoricalLow = &low
+			}
+		}
+		if item.Price != nil {
+			entry.OnSale = item.Price.DiscountPercent > 0
+			entry.BelowTarget = item.TargetPrice > 0 && item.Price.Final <= item.TargetPrice
+			entry.AtHistoricalLow = entry.HistoricalLow != nil && item.Price.Final <= entry.HistoricalLow.Final
+		}
+		entry.Released = !item.ComingSoon && !item.LastChecked.IsZero()
+
+		switch filter {
+		case "on_sale":
+			if !entry.OnSale {
+				continue
+			}
+		case "below_target":
+			if !entry.BelowTarget {
+				continue
+			}
+		case "coming_soon":
+			if !item.ComingSoon {
+				continue
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Priority < entries[j].Priority
+	})
+
+	// 4. Send response
+	respondWithJSON(w, http.StatusOK, entries)
+}
+
+func (h *SteamHandlers) HandleWishlistAlerts(w http.ResponseWriter, r *http.Request) {
+	steamID, err := h.steamAuth.GetSteamID(r)
+	if err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	var wishlist Wishlist
+	if _, err := h.client.Store.Get(wishlistKey(steamID), &wishlist); err != nil {
+		respondWithError(w, r, err, "Failed to read wishlist")
+		return
+	}
+
+	// Newest first
+	alerts := make([]WishlistAlert, 0, len(wishlist.Alerts))
+	for i := len(wishlist.Alerts) - 1; i >= 0; i-- {
+		alerts = append(alerts, wishlist.Alerts[i])
+	}
+
+	respondWithJSON(w, http.StatusOK, alerts)
+}
+
+func (h *SteamHandlers) HandleWishlistTarget(w http.ResponseWriter, r *http.Request) {
+	steamID, err := h.steamAuth.GetSteamID(r)
+	if err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	appID, err := strconv.Atoi(chi.URLParam(r, "appid"))
+	if err != nil {
+		respondWithError(w, r, errInvalidRequest, "Invalid appid")
+		return
+	}
+
+	// Prices are in cents, like the store sends them. 0 removes the target
+	target, err := strconv.Atoi(r.URL.Query().Get("price"))
+	if err != nil || target < 0 {
+		respondWithError(w, r, errInvalidRequest, "Invalid price, expected an amount in cents")
+		return
+	}
+
+	var (
+		wishlist Wishlist
+		updated  *WishlistItem
+	)
+	err = h.client.Store.Update(wishlistKey(steamID), &wishlist, func() error {
+		for i := range wishlist.Items {
+			if wishlist.Items[i].AppID == appID {
+				wishlist.Items[i].TargetPrice = target
+				updated = &wishlist.Items[i]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		respondWithError(w, r, err, "Failed to save price target")
+		return
+	}
+	if updated == nil {
+		respondWithError(w, r, errNotFound, "Game not in wishlist")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, updated)
+}
+
+func (h *SteamHandlers) HandleWishlistHistory(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.steamAuth.GetSteamID(r); err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	appID, err := strconv.Atoi(chi.URLParam(r, "appid"))
+	if err != nil {
+		respondWithError(w, r, errInvalidRequest, "Invalid appid")
+		return
+	}
+
+	cc := LocaleFromContext(r.Context()).Country
+	respondWithJSON(w, http.StatusOK, h.priceHistory(cc, appID))
+}
@@ -1,50 +1,75 @@
 package handlers
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
+	"strconv"
+
+	"github.com/masintxi/gamehub/internal/steam"
 )
 
-func (h *SteamHandlers) GetGameData(gameID string) GameData {
+// GetGameData returns the store data of a game, falling back to the catalog
+// when the store can't be reached.
+func (h *SteamHandlers) GetGameData(gameID string, loc Locale) steam.GameData {
+	gameData, err := h.fetchGameData(gameID, loc)
+	if err != nil {
+		log.Printf("Error fetching game data: %v", err)
+		return h.catalogGameData(gameID)
+	}
 
-	// 2. Make the request
-	url := fmt.Sprintf("https://store.steampowered.com/api/appdetails?appids=%s", gameID)
+	return gameData
+}
 
-	headers := map[string]string{
-		"User-Agent": "Mozilla/5.0",
-	}
+// fetchGameData returns the store data of a game. Unlike GetGameData it
+// reports rate limits and upstream errors instead of falling back.
+func (h *SteamHandlers) fetchGameData(gameID string, loc Locale) (steam.GameData, error) {
+	url := fmt.Sprintf("https://store.steampowered.com/api/appdetails?appids=%s&l=%s&cc=%s",
+		gameID, loc.Language, loc.Country)
 
-	bodyBytes, err := h.getResponseBody(url, headers)
+	bodyBytes, err := h.getResponseBody(url)
 	if err != nil {
-		log.Printf("Error fetching inventory: %v", err)
-		return GameData{}
+		return steam.GameData{}, err
+	}
+
+	var gameData map[string]steam.GameData
+	if err := decodeSteam(bodyBytes, &gameData); err != nil {
+		return steam.GameData{}, fmt.Errorf("decoding game data: %w", err)
 	}
 
-	var gameData map[string]GameData
-	err = json.Unmarshal(bodyBytes, &gameData)
+	return gameData[gameID], nil
+}
+
+// catalogGameData is the offline fallback: it only knows the name, and keeps
+// Success false so callers don't mistake it for store data.
+func (h *SteamHandlers) catalogGameData(gameID string) steam.GameData {
+	var gameData steam.GameData
+
+	appID, err := strconv.Atoi(gameID)
 	if err != nil {
-		log.Println("Error unmarshalling response:", err)
-		return GameData{}
+		return gameData
 	}
 
-	return gameData[gameID]
+	if app, ok := h.client.Catalog.Lookup(appID); ok {
+		gameData.Data.SteamAppid = app.AppID
+		gameData.Data.Name = app.Name
+	}
+	return gameData
 }
 
-func (h *SteamHandlers) GetGameStats(gameID string) {
+func (h *SteamHandlers) GetGameSchema(gameID string, loc Locale) (steam.GameSchemaResponse, error) {
 	//url := fmt.Sprintf("https://store.steampowered.com/api/appdetails?appids=%s&l=english&cc=US&filters=priceoverview", gameID)
 	//url := fmt.Sprintf("https://api.steampowered.com/ISteamUserStats/GetNumberOfCurrentPlayers/v1/?appid=%s", gameID)
-	url := fmt.Sprintf("https://api.steampowered.com/ISteamUserStats/GetSchemaForGame/v2/?key=%s&appid=%s", h.steamAuth.GetAPIKey(), gameID)
+	url := fmt.Sprintf("https://api.steampowered.com/ISteamUserStats/GetSchemaForGame/v2/?key=%s&appid=%s&l=%s", h.steamAuth.GetAPIKey(), gameID, loc.Language)
 
-	headers := map[string]string{
-		"User-Agent": "Mozilla/5.0",
+	bodyBytes, err := h.getResponseBody(url)
+	if err != nil {
+		return steam.GameSchemaResponse{}, err
 	}
 
-	bodyBytes, err := h.getResponseBody(url, headers)
-	if err != nil {
-		log.Printf("Error fetching inventory: %v", err)
-		return
+	var schema steam.GameSchemaResponse
+	if err := decodeSteam(bodyBytes, &schema); err != nil {
+		return steam.GameSchemaResponse{}, fmt.Errorf("decoding game schema: %w", err)
 	}
 
-	fmt.Println("Response:", string(bodyBytes))
+	return schema, nil
 }
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+// Steam refuses community inventory pages larger than this.
+const inventoryPageSize = 2000
+
+// fetchInventory walks the start_assetid cursor until Steam reports no more
+// items. Every page is stored in the cache under its own URL, so when a large
+// inventory trips the rate limit halfway, the next call replays the pages it
+// already has and resumes from the first missing one.
+func (h *SteamHandlers) fetchInventory(steamID, appID, contextID string, loc Locale) ([]InventoryItem, int, error) {
+	items := []InventoryItem{}
+	total := 0
+	startAssetID := ""
+	for {
+		url := fmt.Sprintf("https://steamcommunity.com/inventory/%s/%s/%s?l=%s&count=%d",
+			steamID, appID, contextID, loc.Language, inventoryPageSize)
+		if startAssetID != "" {
+			url += "&start_assetid=" + startAssetID
+		}
+
+		bodyBytes, err := h.getResponseBody(url)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var page steam.InventoryResponse
+		if err := decodeSteam(bodyBytes, &page); err != nil {
+			return nil, 0, fmt.Errorf("decoding inventory page: %w", err)
+		}
+
+		pageItems, err := mergeInventoryPage(page)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: inventory page: %v", errDecode, err)
+		}
+		items = append(items, pageItems...)
+		total = page.TotalInventoryCount
+
+		if page.MoreItems == 0 || page.LastAssetid == "" {
+			break
+		}
+		startAssetID = page.LastAssetid
+	}
+
+	return items, total, nil
+}
+
+// mergeInventoryPage joins every asset of a page with its description and
+// normalizes its tags.
+func mergeInventoryPage(page steam.InventoryResponse) ([]InventoryItem, error) {
+	steamItems, err := steam.NewInventoryItems(page)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]InventoryItem, len(steamItems))
+	for i, steamItem := range steamItems {
+		items[i] = InventoryItem{InventoryItem: steamItem}
+		normalizeItemTags(&items[i])
+	}
+
+	return items, nil
+}
+
+// normalizeItemTags maps the per-game tag categories onto the common item
+// fields. CS2, TF2 and Dota 2 share the Type/Quality/Rarity categories, while
+// Steam Community items describe the same things with item_class, cardborder
+// and droprate.
+func normalizeItemTags(item *InventoryItem) {
+	for _, category := range slices.Sorted(maps.Keys(item.Tags)) {
+		value := item.Tags[category]
+		switch strings.ToLower(category) {
+		case "type", "item_class":
+			item.Category = value
+		case "rarity", "droprate":
+			item.Rarity = value
+		case "quality", "cardborder":
+			item.Quality = value
+		case "exterior":
+			item.Exterior = value
+		case "game":
+			item.Game = value
+		case "hero", "class":
+			item.UsedBy = value
+		}
+	}
+}
+
+// fetchInventoryApps reads the app inventories listed on the profile
+// inventory page. Steam has no Web API for it, but the page embeds the same
+// data the inventory tabs are built from.
+func (h *SteamHandlers) fetchInventoryApps(steamID string, loc Locale) ([]InventoryApp, error) {
+	url := fmt.Sprintf("https://steamcommunity.com/profiles/%s/inventory/?l=%s", steamID, loc.Language)
+
+	bodyBytes, err := h.getResponseBody(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseInventoryApps(string(bodyBytes))
+}
+
+func parseInventoryApps(page string) ([]InventoryApp, error) {
+	const marker = "g_rgAppContextData ="
+	i := strings.Index(page, marker)
+	if i < 0 && strings.Contains(page, "profile_private_info") {
+		return nil, fmt.Errorf("%w: inventory page", errPrivateProfile)
+	}
+	if i < 0 {
+		return nil, fmt.Errorf("%w: inventory app data not found in profile page", errDecode)
+	}
+
+	var contextData map[string]struct {
+		AppID      int                         `json:"appid"`
+		Name       string                      `json:"name"`
+		Icon       string                      `json:"icon"`
+		AssetCount int                         `json:"asset_count"`
+		Contexts   map[string]InventoryContext `json:"rgContexts"`
+	}
+	if err := json.NewDecoder(strings.NewReader(page[i+len(marker):])).Decode(&contextData); err != nil {
+		return nil, fmt.Errorf("%w: inventory app data: %v", errDecode, err)
+	}
+
+	apps := make([]InventoryApp, 0, len(contextData))
+	for _, data := range contextData {
+		app := InventoryApp{
+			AppID:      data.AppID,
+			Name:       data.Name,
+			Icon:       data.Icon,
+			AssetCount: data.AssetCount,
+			Contexts:   make([]InventoryContext, 0, len(data.Contexts)),
+		}
+		for _, ctx := range data.Contexts {
+			app.Contexts = append(app.Contexts, ctx)
+		}
+		sort.Slice(app.Contexts, func(i, j int) bool {
+			return app.Contexts[i].ID < app.Contexts[j].ID
+		})
+		apps = append(apps, app)
+	}
+
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].AppID < apps[j].AppID
+	})
+
+	return apps, nil
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+func TestMergeInventoryPage(t *testing.T) {
+	raw := `{
+		"assets": [
+			{"appid": 753, "contextid": "6", "assetid": "1", "classid": "100", "instanceid": "0", "amount": "1"},
+			{"appid": 753, "contextid": "6", "assetid": "2", "classid": "200", "instanceid": "0", "amount": "3"},
+			{"appid": 753, "contextid": "6", "assetid": "3", "classid": "300", "instanceid": "0", "amount": "1"}
+		],
+		"descriptions": [
+			{"appid": 753, "classid": "100", "instanceid": "0", "name": "Card A", "market_hash_name": "10-Card A", "marketable": 1,
+				"tags": [{"category": "item_class", "localized_tag_name": "Trading Card"}, {"category": "Game", "localized_tag_name": "Portal"}]},
+			{"appid": 753, "classid": "200", "instanceid": "0", "name": "Gems", "market_hash_name": "753-Sack of Gems", "tradable": 1}
+		],
+		"more_items": 1,
+		"last_assetid": "3",
+		"total_inventory_count": 5,
+		"success": 1
+	}`
+
+	var page steam.InventoryResponse
+	if err := json.Unmarshal([]byte(raw), &page); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	items, err := mergeInventoryPage(page)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(items))
+	}
+	if items[0].MarketHashName != "10-Card A" || !items[0].Marketable {
+		t.Errorf("expected first item to be joined with its description, got %+v", items[0])
+	}
+	if items[0].Category != "Trading Card" || items[0].Game != "Portal" {
+		t.Errorf("expected first item tags to be normalized, got %+v", items[0])
+	}
+	if items[1].Amount != 3 || !items[1].Tradable {
+		t.Errorf("expected second item to keep its amount, got %+v", items[1])
+	}
+	if items[2].Name != "" || items[2].AssetID != 3 {
+		t.Errorf("expected item without description to keep asset fields only, got %+v", items[2])
+	}
+}
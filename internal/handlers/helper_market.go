@@ -0,0 +1,385 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/masintxi/gamehub/internal/currency"
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+// The listing page embeds the sales history for its chart, the item_nameid
+// the order book is loaded with and, for logged in users, the wallet.
+var (
+	listingHistoryRegExp  = regexp.MustCompile(`var line1=(\[.*?\]);`)
+	listingNameIDRegExp   = regexp.MustCompile(`Market_LoadOrderSpread\(\s*(\d+)\s*\)`)
+	listingCurrencyRegExp = regexp.MustCompile(`"wallet_currency":(\d+)`)
+)
+
+const (
+	// Dates of the history points, such as "Nov 27 2013 01: +0"
+	listingDateLayout = "Jan 02 2006 15"
+
+	// Price overviews follow the sales of the day, so a few minutes is fresh
+	// enough and spares the strict rate limit of the endpoint
+	priceOverviewTTL = 5 * time.Minute
+
+	nameIDKeyPrefix = "nameid:"
+)
+
+type listingPage struct {
+	NameID   int
+	Currency string
+	History  []MarketHistoryPoint
+}
+
+// fetchListingPage reads the market listing page of an item. It is public,
+// but prices come in the wallet currency of the market session.
+func (h *SteamHandlers) fetchListingPage(appID int, marketHashName string, loc Locale) (listingPage, error) {
+	pageURL := fmt.Sprintf("https://steamcommunity.com/market/listings/%d/%s?l=%s",
+		appID, url.PathEscape(marketHashName), loc.Language)
+
+	bodyBytes, err := h.getResponseBody(pageURL)
+	if err != nil {
+		return listingPage{}, err
+	}
+
+	return parseListingPage(string(bodyBytes))
+}
+
+// resolveNameID finds the item_nameid the order book endpoints take for an
+// item. It never changes, so it is kept in the store for good and the
+// listing page is only read the first time. Only routes that need a login
+// call it, so anonymous requests can't fill the store with names.
+func (h *SteamHandlers) resolveNameID(appID int, marketHashName string) (int, error) {
+	var nameID int
+	ok, err := h.client.Store.Get(nameIDKey(appID, marketHashName), &nameID)
+	if err != nil {
+		log.Printf("Error reading item_nameid: %v", err)
+	}
+	if ok && nameID != 0 {
+		return nameID, nil
+	}
+
+	listing, err := h.fetchListingPage(appID, marketHashName, Locale{Language: defaultLanguage})
+	if err != nil {
+		return 0, err
+	}
+	if listing.NameID == 0 {
+		return 0, fmt.Errorf("%w: no order book for %s", errNotFound, marketHashName)
+	}
+
+	if err := h.client.Store.Put(nameIDKey(appID, marketHashName), listing.NameID); err != nil {
+		log.Printf("Error saving item_nameid of %s: %v", marketHashName, err)
+	}
+	return listing.NameID, nil
+}
+
+// resolveNameIDs resolves a batch of items, one listing page at a time.
+// Items Steam doesn't know are reported in the result; a rate limit stops
+// the batch, and the items resolved so far are already saved for the retry.
+func (h *SteamHandlers) resolveNameIDs(items []MarketItemNameID) ([]MarketItemNameID, error) {
+	resolved := make([]MarketItemNameID, 0, len(items))
+	for _, item := range items {
+		nameID, err := h.resolveNameID(item.AppID, item.MarketHashName)
+		if errors.Is(err, errRateLimited) || errors.Is(err, errUpstreamDown) {
+			return resolved, err
+		}
+		if err != nil {
+			item.Error = err.Error()
+		}
+		item.NameID = nameID
+		resolved = append(resolved, item)
+	}
+	return resolved, nil
+}
+
+// fetchPriceOverview reads the lowest and median prices of an item in the
+// currency of the request.
+func (h *SteamHandlers) fetchPriceOverview(appID int, marketHashName string, loc Locale) (MarketOverview, error) {
+	code, _ := marketCurrency(loc)
+
+	bodyBytes, err := h.getResponseBodyWithTTL(priceOverviewURL(appID, marketHashName, loc), priceOverviewTTL)
+	if err != nil {
+		return MarketOverview{}, err
+	}
+
+	var raw steam.MarketPriceOverview
+	if err := decodeSteam(bodyBytes, &raw); err != nil {
+		return MarketOverview{}, fmt.Errorf("decoding price overview: %w", err)
+	}
+
+	// Volumes use the thousands separator of the currency
+	volume, _ := strconv.Atoi(strings.NewReplacer(",", "", ".", "", " ", "").Replace(raw.Volume))
+	return MarketOverview{
+		AppID:                appID,
+		MarketHashName:       marketHashName,
+		Currency:             code,
+		LowestPrice:          parseMarketPrice(raw.LowestPrice),
+		MedianPrice:          parseMarketPrice(raw.MedianPrice),
+		LowestPriceFormatted: raw.LowestPrice,
+		MedianPriceFormatted: raw.MedianPrice,
+		Volume:               volume,
+	}, nil
+}
+
+// priceOverviewCached tells whether a price overview can be read without
+// going to Steam.
+func (h *SteamHandlers) priceOverviewCached(appID int, marketHashName string, loc Locale) bool {
+	_, ok := h.client.Cache.Get(priceOverviewURL(appID, marketHashName, loc))
+	return ok
+}
+
+func priceOverviewURL(appID int, marketHashName string, loc Locale) string {
+	_, currencyID := marketCurrency(loc)
+
+	q := url.Values{}
+	q.Set("appid", strconv.Itoa(appID))
+	q.Set("currency", strconv.Itoa(currencyID))
+	q.Set("market_hash_name", marketHashName)
+	return "https://steamcommunity.com/market/priceoverview/?" + q.Encode()
+}
+
+// parseMarketPrice returns nil for the prices Steam leaves out, such as the
+// median of items without sales in the last day.
+func parseMarketPrice(text string) *int {
+	if text == "" {
+		return nil
+	}
+	price, err := steam.ParsePrice(text)
+	if err != nil {
+		log.Printf("Unexpected market price: %v", err)
+		return nil
+	}
+	return &price
+}
+
+// fetchOrderBook reads the buy and sell orders of an item. They change by
+// the second, so they always come from Steam.
+func (h *SteamHandlers) fetchOrderBook(nameID int, loc Locale) (OrderBook, error) {
+	code, currencyID := marketCurrency(loc)
+
+	q := url.Values{}
+	q.Set("country", strings.ToUpper(loc.Country))
+	q.Set("language", loc.Language)
+	q.Set("currency", strconv.Itoa(currencyID))
+	q.Set("item_nameid", strconv.Itoa(nameID))
+
+	// The market session cookies are added by the client transport
+	bodyBytes, err := h.fetchResponseBody("https://steamcommunity.com/market/itemordershistogram?" + q.Encode())
+	if err != nil {
+		return OrderBook{}, err
+	}
+
+	var histogram steam.OrderHistogramResponse
+	if err := decodeSteam(bodyBytes, &histogram); err != nil {
+		return OrderBook{}, fmt.Errorf("decoding order book: %w", err)
+	}
+
+	book := newOrderBook(histogram)
+	book.NameID = nameID
+	book.Currency = code
+	return book, nil
+}
+
+// newOrderBook turns the cumulative order graphs into price levels. Bids
+// go from the highest price down and asks from the lowest up, as Steam
+// sends them.
+func newOrderBook(histogram steam.OrderHistogramResponse) OrderBook {
+	book := OrderBook{
+		Bids: orderLevels(histogram.BuyOrderGraph),
+		Asks: orderLevels(histogram.SellOrderGraph),
+	}
+	if n := len(book.Bids); n > 0 {
+		book.BidDepth = book.Bids[n-1].Cumulative
+	}
+	if n := len(book.Asks); n > 0 {
+		book.AskDepth = book.Asks[n-1].Cumulative
+	}
+
+	if bid, err := strconv.Atoi(histogram.HighestBuyOrder); err == nil {
+		book.HighestBid = &bid
+	}
+	if ask, err := strconv.Atoi(histogram.LowestSellOrder); err == nil {
+		book.LowestAsk = &ask
+	}
+	if book.HighestBid != nil && book.LowestAsk != nil {
+		spread := *book.LowestAsk - *book.HighestBid
+		book.Spread = &spread
+		if *book.LowestAsk > 0 {
+			percent := float64(spread) * 100 / float64(*book.LowestAsk)
+			book.SpreadPercent = &percent
+		}
+	}
+	return book
+}
+
+func orderLevels(graph []steam.OrderGraphPoint) []OrderLevel {
+	levels := make([]OrderLevel, 0, len(graph))
+	previous := 0
+	for _, point := range graph {
+		levels = append(levels, OrderLevel{
+			Price:      int(math.Round(point.Price * 100)),
+			Quantity:   point.Quantity - previous,
+			Cumulative: point.Quantity,
+		})
+		previous = point.Quantity
+	}
+	return levels
+}
+
+// fetchListings reads a page of the sell listings of an item, cheapest
+// first, with the amounts converted to the currency of the request.
+func (h *SteamHandlers) fetchListings(appID int, marketHashName string, start, count int, loc Locale) (MarketListings, error) {
+	code, currencyID := marketCurrency(loc)
+
+	q := url.Values{}
+	q.Set("start", strconv.Itoa(start))
+	q.Set("count", strconv.Itoa(count))
+	q.Set("country", strings.ToUpper(loc.Country))
+	q.Set("language", loc.Language)
+	q.Set("currency", strconv.Itoa(currencyID))
+	q.Set("format", "json")
+
+	listingsURL := fmt.Sprintf("https://steamcommunity.com/market/listings/%d/%s/render/?%s",
+		appID, url.PathEscape(marketHashName), q.Encode())
+	bodyBytes, err := h.fetchResponseBody(listingsURL)
+	if err != nil {
+		return MarketListings{}, err
+	}
+
+	var raw steam.ListingsResponse
+	if err := decodeSteam(bodyBytes, &raw); err != nil {
+		return MarketListings{}, fmt.Errorf("decoding listings: %w", err)
+	}
+
+	result := MarketListings{
+		AppID:          appID,
+		MarketHashName: marketHashName,
+		Currency:       code,
+		Start:          raw.Start,
+		TotalCount:     raw.TotalCount,
+		Listings:       make([]MarketListing, 0, len(raw.ListingInfo)),
+	}
+	for _, listing := range raw.ListingInfo {
+		price, fee := listing.Price, listing.Fee
+		if listing.ConvertedCurrencyID != 0 {
+			price, fee = listing.ConvertedPrice, listing.ConvertedFee
+		}
+		amount, _ := strconv.Atoi(listing.Asset.Amount)
+		result.Listings = append(result.Listings, MarketListing{
+			ListingID:      listing.ListingID,
+			AssetID:        listing.Asset.ID,
+			Amount:         amount,
+			Price:          price + fee,
+			SellerReceives: price,
+			Fee:            fee,
+		})
+	}
+	sort.SliceStable(result.Listings, func(i, j int) bool {
+		if result.Listings[i].Price != result.Listings[j].Price {
+			return result.Listings[i].Price < result.Listings[j].Price
+		}
+		return result.Listings[i].ListingID < result.Listings[j].ListingID
+	})
+
+	return result, nil
+}
+
+// marketCurrency returns the currency market prices are asked in. The
+// market falls back to USD for currencies without a Steam wallet.
+func marketCurrency(loc Locale) (string, int) {
+	if id, ok := currency.SteamID(loc.Currency); ok {
+		return strings.ToUpper(loc.Currency), id
+	}
+	return "USD", 1
+}
+
+func nameIDKey(appID int, marketHashName string) string {
+	return fmt.Sprintf("%s%d:%s", nameIDKeyPrefix, appID, marketHashName)
+}
+
+func parseListingPage(page string) (listingPage, error) {
+	listing := listingPage{Currency: "USD"}
+
+	if match := listingNameIDRegExp.FindStringSubmatch(page); match != nil {
+		listing.NameID, _ = strconv.Atoi(match[1])
+	}
+	if match := listingCurrencyRegExp.FindStringSubmatch(page); match != nil {
+		id, _ := strconv.Atoi(match[1])
+		if code, ok := currency.Code(id); ok {
+			listing.Currency = code
+		}
+	}
+
+	match := listingHistoryRegExp.FindStringSubmatch(page)
+	if match == nil {
+		if listing.NameID == 0 {
+			return listingPage{}, fmt.Errorf("%w: no listing for this item", errNotFound)
+		}
+		listing.History = []MarketHistoryPoint{}
+		return listing, nil
+	}
+
+	var points [][]json.RawMessage
+	if err := json.Unmarshal([]byte(match[1]), &points); err != nil {
+		return listingPage{}, fmt.Errorf("%w: price history: %v", errDecode, err)
+	}
+
+	listing.History = make([]MarketHistoryPoint, 0, len(points))
+	for _, raw := range points {
+		point, err := parseHistoryPoint(raw)
+		if err != nil {
+			return listingPage{}, fmt.Errorf("%w: price history: %v", errDecode, err)
+		}
+		listing.History = append(listing.History, point)
+	}
+
+	return listing, nil
+}
+
+// parseHistoryPoint reads a [date, median price, volume] entry. The price
+// comes in major units and the volume as a string.
+func parseHistoryPoint(raw []json.RawMessage) (MarketHistoryPoint, error) {
+	if len(raw) < 3 {
+		return MarketHistoryPoint{}, fmt.Errorf("expected 3 values, got %d", len(raw))
+	}
+
+	var date, volume string
+	var price float64
+	var point MarketHistoryPoint
+	if err := json.Unmarshal(raw[0], &date); err != nil {
+		return MarketHistoryPoint{}, fmt.Errorf("date: %v", err)
+	}
+	if err := json.Unmarshal(raw[1], &price); err != nil {
+		return MarketHistoryPoint{}, fmt.Errorf("price: %v", err)
+	}
+	point.Price = int(math.Round(price * 100))
+	if err := json.Unmarshal(raw[2], &volume); err != nil {
+		return MarketHistoryPoint{}, fmt.Errorf("volume: %v", err)
+	}
+
+	// The hour ends with a colon and the UTC offset, which is always +0
+	date, _, _ = strings.Cut(date, ":")
+	t, err := time.Parse(listingDateLayout, date)
+	if err != nil {
+		return MarketHistoryPoint{}, fmt.Errorf("date: %v", err)
+	}
+	point.Time = t
+
+	point.Volume, err = strconv.Atoi(volume)
+	if err != nil {
+		return MarketHistoryPoint{}, fmt.Errorf("volume: %v", err)
+	}
+
+	return point, nil
+}
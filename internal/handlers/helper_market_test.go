@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+func TestParseListingPage(t *testing.T) {
+	page := `<html><body>
+<script type="text/javascript">
+	var g_rgWalletInfo = {"wallet_currency":3,"wallet_country":"ES","wallet_fee":1};
+	var line1=[["Nov 27 2013 01: +0",4.457,"1"],["Nov 28 2013 01: +0",2.3,"15"],["Mar 04 2025 14: +0",0.031,"1204"]];
+	$J(function() {
+		Market_LoadOrderSpread( 150084592 );	// initial load
+	});
+</script>
+</body></html>`
+
+	listing, err := parseListingPage(page)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if listing.NameID != 150084592 || listing.Currency != "EUR" {
+		t.Errorf("unexpected listing %+v", listing)
+	}
+	if len(listing.History) != 3 {
+		t.Fatalf("expected 3 points, got %d", len(listing.History))
+	}
+
+	if first := listing.History[0]; first.Price != 446 {
+		t.Errorf("expected the price in minor units, got %+v", first)
+	}
+	last := listing.History[2]
+	if want := time.Date(2025, 3, 4, 14, 0, 0, 0, time.UTC); !last.Time.Equal(want) {
+		t.Errorf("expected time %v, got %v", want, last.Time)
+	}
+	if last.Price != 3 || last.Volume != 1204 {
+		t.Errorf("unexpected point %+v", last)
+	}
+}
+
+func TestParseListingPageErrors(t *testing.T) {
+	_, err := parseListingPage(`<div class="market_listing_table_message">There are no listings for this item.</div>`)
+	if !errors.Is(err, errNotFound) {
+		t.Errorf("expected not found without a listing, got %v", err)
+	}
+
+	_, err = parseListingPage(`var line1=[["yesterday",1.5,"3"]]; Market_LoadOrderSpread( 1 );`)
+	if !errors.Is(err, errDecode) {
+		t.Errorf("expected a decode error for a bad date, got %v", err)
+	}
+
+	// Items without sales still have an order book
+	listing, err := parseListingPage(`Market_LoadOrderSpread( 42 );`)
+	if err != nil || listing.NameID != 42 || listing.Currency != "USD" || len(listing.History) != 0 {
+		t.Errorf("unexpected listing %+v, %v", listing, err)
+	}
+}
+
+func TestResolveNameIDs(t *testing.T) {
+	h := newReplayHandlers(t)
+
+	resolved, err := h.resolveNameIDs([]MarketItemNameID{
+		{AppID: 730, MarketHashName: "Revolution Case"},
+		{AppID: 730, MarketHashName: "Unknown Case"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resolved) != 2 || resolved[0].NameID != 176000103 || resolved[0].Error != "" {
+		t.Fatalf("unexpected result %+v", resolved)
+	}
+	if resolved[1].NameID != 0 || resolved[1].Error == "" {
+		t.Errorf("expected an error for an unknown item, got %+v", resolved[1])
+	}
+
+	// The mapping is kept, so the listing page is only read once
+	var nameID int
+	if ok, _ := h.client.Store.Get(nameIDKey(730, "Revolution Case"), &nameID); !ok || nameID != 176000103 {
+		t.Errorf("expected the item_nameid to be stored, got %d", nameID)
+	}
+	h.client.Cache.ClearMemoryCache()
+	if nameID, err := h.resolveNameID(730, "Revolution Case"); err != nil || nameID != 176000103 {
+		t.Errorf("unexpected item_nameid %d, %v", nameID, err)
+	}
+	if requests := h.client.Metrics.Snapshot()["steamcommunity.com"].Requests; requests != 2 {
+		t.Errorf("expected 2 upstream requests, got %d", requests)
+	}
+}
+
+func TestFetchListingPageKeepsStore(t *testing.T) {
+	h := newReplayHandlers(t)
+
+	// Reading the history of an item saves nothing, only resolving it does
+	listing, err := h.fetchListingPage(730, "Revolution Case", Locale{Language: defaultLanguage})
+	if err != nil || listing.NameID != 176000103 {
+		t.Fatalf("unexpected listing %+v, %v", listing, err)
+	}
+	if keys := h.client.Store.Keys(nameIDKeyPrefix); len(keys) != 0 {
+		t.Errorf("expected no stored item_nameids, got %v", keys)
+	}
+}
+
+func TestNewOrderBook(t *testing.T) {
+	raw := `{
+		"success": 1,
+		"highest_buy_order": "3000",
+		"lowest_sell_order": "3150",
+		"buy_order_graph": [[30, 12, "12 buy orders at $30.00 or higher"], [29.5, 20, "20 buy orders at $29.50 or higher"], [29.01, 45, "45 buy orders at $29.01 or higher"]],
+		"sell_order_graph": [[31.5, 3, "3 sell orders at $31.50 or lower"], [32, 10, "10 sell orders at $32.00 or lower"]],
+		"price_prefix": "$",
+		"price_suffix": ""
+	}`
+
+	var histogram steam.OrderHistogramResponse
+	if err := decodeSteam([]byte(raw), &histogram); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	book := newOrderBook(histogram)
+	if book.HighestBid == nil || *book.HighestBid != 3000 || book.LowestAsk == nil || *book.LowestAsk != 3150 {
+		t.Fatalf("unexpected best prices %v, %v", book.HighestBid, book.LowestAsk)
+	}
+	if *book.Spread != 150 || *book.SpreadPercent < 4.76 || *book.SpreadPercent > 4.77 {
+		t.Errorf("unexpected spread %d (%.2f%%)", *book.Spread, *book.SpreadPercent)
+	}
+	if book.BidDepth != 45 || book.AskDepth != 10 {
+		t.Errorf("unexpected depth %d bids, %d asks", book.BidDepth, book.AskDepth)
+	}
+	if want := (OrderLevel{Price: 2901, Quantity: 25, Cumulative: 45}); book.Bids[2] != want {
+		t.Errorf("expected %+v, got %+v", want, book.Bids[2])
+	}
+	if want := (OrderLevel{Price: 3200, Quantity: 7, Cumulative: 10}); book.Asks[1] != want {
+		t.Errorf("expected %+v, got %+v", want, book.Asks[1])
+	}
+
+	// Without buy orders there is no spread
+	empty := newOrderBook(steam.OrderHistogramResponse{LowestSellOrder: "52"})
+	if empty.HighestBid != nil || empty.Spread != nil || len(empty.Bids) != 0 {
+		t.Errorf("expected an order book without bids, got %+v", empty)
+	}
+}
+
+func TestFetchPriceOverview(t *testing.T) {
+	h := newReplayHandlers(t)
+	loc := Locale{Language: defaultLanguage, Country: "es", Currency: "EUR"}
+
+	overview, err := h.fetchPriceOverview(730, "Revolution Case", loc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if overview.Currency != "EUR" || overview.Volume != 12418 || overview.LowestPriceFormatted != "1,95€" {
+		t.Errorf("unexpected overview %+v", overview)
+	}
+	if overview.LowestPrice == nil || *overview.LowestPrice != 195 || overview.MedianPrice == nil || *overview.MedianPrice != 123456 {
+		t.Errorf("unexpected prices %v, %v", overview.LowestPrice, overview.MedianPrice)
+	}
+
+	// Repeated calls are answered from the cache
+	if _, err := h.fetchPriceOverview(730, "Revolution Case", loc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests := h.client.Metrics.Snapshot()["steamcommunity.com"].Requests; requests != 1 {
+		t.Errorf("expected 1 upstream request, got %d", requests)
+	}
+}
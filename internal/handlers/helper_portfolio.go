@@ -0,0 +1,316 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	portfolioKeyPrefix = "portfolio:"
+
+	// Snapshots kept per portfolio, about eight months at the default interval
+	maxPortfolioSnapshots = 1000
+
+	// Inventories too large to price at once are priced in rounds. Five
+	// rounds a minute apart finish before the overviews of the first one
+	// leave the cache.
+	maxSnapshotRounds = 5
+
+	// Items listed as gainers and as losers
+	portfolioMovers = 5
+)
+
+// Wait between the rounds of a snapshot, a variable so tests don't wait
+var snapshotRoundWait = time.Minute
+
+// trackPortfolio starts keeping snapshots of an inventory, valued in the
+// currency of loc from then on.
+func (h *SteamHandlers) trackPortfolio(steamID, appID, contextID string, loc Locale) (Portfolio, error) {
+	code, _ := marketCurrency(loc)
+
+	var portfolio Portfolio
+	err := h.client.Store.Update(portfolioKey(steamID, appID, contextID), &portfolio, func() error {
+		if portfolio.Currency != "" && portfolio.Currency != code {
+			// Mixing currencies would break the series
+			return fmt.Errorf("%w: portfolio already tracked in %s", errInvalidRequest, portfolio.Currency)
+		}
+		portfolio.SteamID = steamID
+		portfolio.AppID = appID
+		portfolio.ContextID = contextID
+		portfolio.Currency = code
+		return nil
+	})
+	return portfolio, err
+}
+
+// snapshotPortfolio values a tracked inventory and records the snapshot.
+// Items that still have no price after every round keep the one of the
+// previous snapshot. The items priced longest ago go first, so inventories
+// too large for one snapshot are fully priced over the next ones.
+func (h *SteamHandlers) snapshotPortfolio(steamID, appID, contextID string) error {
+	key := portfolioKey(steamID, appID, contextID)
+
+	var portfolio Portfolio
+	ok, err := h.client.Store.Get(key, &portfolio)
+	if err != nil || !ok {
+		return err
+	}
+
+	loc := h.userLocale(steamID)
+	loc.Currency = portfolio.Currency
+
+	items, _, err := h.fetchInventory(steamID, appID, contextID, loc)
+	if err != nil {
+		return err
+	}
+
+	groups := groupInventoryValues(items)
+	repriceOrder(groups, lastSnapshot(portfolio))
+
+	valuation := h.priceInventoryGroups(slices.Clone(groups), loc)
+	for round := 1; !valuation.Complete && round < maxSnapshotRounds; round++ {
+		time.Sleep(snapshotRoundWait)
+		valuation = h.priceInventoryGroups(slices.Clone(groups), loc)
+	}
+
+	// The portfolio may have been untracked or snapshotted meanwhile, so the
+	// snapshot is added to what is stored now
+	var current Portfolio
+	return h.client.Store.Update(key, &current, func() error {
+		if current.Currency == "" {
+			return fmt.Errorf("%w: portfolio no longer tracked", errNotFound)
+		}
+
+		snapshot := newPortfolioSnapshot(valuation, lastSnapshot(current), time.Now().UTC())
+		current.Snapshots = append(current.Snapshots, snapshot)
+		if len(current.Snapshots) > maxPortfolioSnapshots {
+			current.Snapshots = current.Snapshots[len(current.Snapshots)-maxPortfolioSnapshots:]
+		}
+		return nil
+	})
+}
+
+// snapshotPortfolios is the background job going over every tracked
+// portfolio.
+func (h *SteamHandlers) snapshotPortfolios() {
+	for _, key := range h.client.Store.Keys(portfolioKeyPrefix) {
+		parts := strings.Split(strings.TrimPrefix(key, portfolioKeyPrefix), ":")
+		if len(parts) != 3 {
+			continue
+		}
+
+		if err := h.snapshotPortfolio(parts[0], parts[1], parts[2]); err != nil {
+			log.Printf("Error taking portfolio snapshot of %s: %v", key, err)
+		}
+	}
+}
+
+func lastSnapshot(portfolio Portfolio) *PortfolioSnapshot {
+	if n := len(portfolio.Snapshots); n > 0 {
+		return &portfolio.Snapshots[n-1]
+	}
+	return nil
+}
+
+// repriceOrder puts first the groups the previous snapshot had no price
+// for, then the ones priced longest ago.
+func repriceOrder(groups []ItemValuation, previous *PortfolioSnapshot) {
+	pricedAt := make(map[string]time.Time)
+	if previous != nil {
+		for _, item := range previous.Items {
+			if !item.Unpriced {
+				pricedAt[marketItemKey(item.AppID, item.MarketHashName)] = item.PricedAt
+			}
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return pricedAt[marketItemKey(groups[i].AppID, groups[i].MarketHashName)].
+			Before(pricedAt[marketItemKey(groups[j].AppID, groups[j].MarketHashName)])
+	})
+}
+
+// newPortfolioSnapshot records the prices of a valuation. Items Steam had no
+// price for keep the one of the previous snapshot, and those never priced
+// are listed as unpriced.
+func newPortfolioSnapshot(valuation InventoryValuation, previous *PortfolioSnapshot, now time.Time) PortfolioSnapshot {
+	previousItems := make(map[string]PortfolioItem)
+	if previous != nil {
+		for _, item := range previous.Items {
+			if !item.Unpriced {
+				previousItems[marketItemKey(item.AppID, item.MarketHashName)] = item
+			}
+		}
+	}
+
+	snapshot := PortfolioSnapshot{
+		Time:     now,
+		Complete: true,
+		Items:    []PortfolioItem{},
+	}
+	for _, item := range valuation.Items {
+		snapshotItem := PortfolioItem{
+			AppID:          item.AppID,
+			MarketHashName: item.MarketHashName,
+			Name:           item.Name,
+			Count:          item.Count,
+		}
+		if item.Price != nil {
+			snapshotItem.Price = *item.Price
+			snapshotItem.PricedAt = now
+		} else if before, ok := previousItems[marketItemKey(item.AppID, item.MarketHashName)]; ok {
+			snapshotItem.Price = before.Price
+			snapshotItem.PricedAt = before.PricedAt
+			snapshotItem.Stale = true
+		} else {
+			// Items without listings nor sales stay unpriced, but only the
+			// ones still waiting for the budget leave the snapshot incomplete
+			snapshotItem.Unpriced = true
+			if item.Pending {
+				snapshot.Complete = false
+			}
+		}
+
+		snapshot.Value += snapshotItem.Price * snapshotItem.Count
+		snapshot.ValueAfterFees += sellerReceives(snapshotItem.Price) * snapshotItem.Count
+		snapshot.Items = append(snapshot.Items, snapshotItem)
+	}
+
+	return snapshot
+}
+
+// newPortfolioReport charts the snapshots taken after since, compares the
+// latest one with those of a day and a week before and ranks the items by
+// how much their holdings gained or lost over the window.
+func newPortfolioReport(portfolio Portfolio, since time.Time) PortfolioReport {
+	report := PortfolioReport{
+		SteamID:   portfolio.SteamID,
+		AppID:     portfolio.AppID,
+		ContextID: portfolio.ContextID,
+		Currency:  portfolio.Currency,
+		Gainers:   []ItemChange{},
+		Losers:    []ItemChange{},
+		Series:    []PortfolioPoint{},
+	}
+
+	var first *PortfolioSnapshot
+	for i, snapshot := range portfolio.Snapshots {
+		if snapshot.Time.Before(since) {
+			continue
+		}
+		if first == nil {
+			first = &portfolio.Snapshots[i]
+		}
+		report.Series = append(report.Series, newPortfolioPoint(snapshot))
+	}
+
+	n := len(portfolio.Snapshots)
+	if n == 0 {
+		return report
+	}
+	latest := portfolio.Snapshots[n-1]
+	current := newPortfolioPoint(latest)
+	report.Current = &current
+	report.Daily = portfolioChange(portfolio.Snapshots, latest, 24*time.Hour)
+	report.Weekly = portfolioChange(portfolio.Snapshots, latest, 7*24*time.Hour)
+
+	if first == nil {
+		return report
+	}
+	changes := itemChanges(*first, latest)
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Change > changes[j].Change
+	})
+	for _, change := range changes {
+		if change.Change > 0 && len(report.Gainers) < portfolioMovers {
+			report.Gainers = append(report.Gainers, change)
+		}
+	}
+	for i := len(changes) - 1; i >= 0; i-- {
+		if changes[i].Change < 0 && len(report.Losers) < portfolioMovers {
+			report.Losers = append(report.Losers, changes[i])
+		}
+	}
+
+	return report
+}
+
+func newPortfolioPoint(snapshot PortfolioSnapshot) PortfolioPoint {
+	point := PortfolioPoint{
+		Time:           snapshot.Time,
+		Value:          snapshot.Value,
+		ValueAfterFees: snapshot.ValueAfterFees,
+		Complete:       snapshot.Complete,
+	}
+	for _, item := range snapshot.Items {
+		point.Items += item.Count
+	}
+	return point
+}
+
+// portfolioChange compares the latest snapshot with the last one taken at
+// least period before it, or returns nil when the history is shorter.
+func portfolioChange(snapshots []PortfolioSnapshot, latest PortfolioSnapshot, period time.Duration) *PortfolioChange {
+	cutoff := latest.Time.Add(-period)
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		before := snapshots[i]
+		if before.Time.After(cutoff) {
+			continue
+		}
+
+		change := &PortfolioChange{
+			From:   before.Time,
+			To:     latest.Time,
+			Before: before.Value,
+			After:  latest.Value,
+			Change: latest.Value - before.Value,
+		}
+		if before.Value > 0 {
+			change.ChangePercent = float64(change.Change) * 100 / float64(before.Value)
+		}
+		return change
+	}
+	return nil
+}
+
+// itemChanges values the items held in the latest snapshot at both prices,
+// so buying or selling more of an item doesn't count as a gain or a loss.
+func itemChanges(first, latest PortfolioSnapshot) []ItemChange {
+	firstPrices := make(map[string]int, len(first.Items))
+	for _, item := range first.Items {
+		if !item.Unpriced {
+			firstPrices[marketItemKey(item.AppID, item.MarketHashName)] = item.Price
+		}
+	}
+
+	changes := []ItemChange{}
+	for _, item := range latest.Items {
+		before, ok := firstPrices[marketItemKey(item.AppID, item.MarketHashName)]
+		if !ok || item.Unpriced {
+			continue
+		}
+
+		change := ItemChange{
+			AppID:          item.AppID,
+			MarketHashName: item.MarketHashName,
+			Name:           item.Name,
+			Count:          item.Count,
+			PriceBefore:    before,
+			Price:          item.Price,
+			Change:         (item.Price - before) * item.Count,
+		}
+		if before > 0 {
+			change.ChangePercent = float64(item.Price-before) * 100 / float64(before)
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func portfolioKey(steamID, appID, contextID string) string {
+	return fmt.Sprintf("%s%s:%s:%s", portfolioKeyPrefix, steamID, appID, contextID)
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSnapshotPortfolio(t *testing.T) {
+	h := newReplayHandlers(t)
+	const steamID = "76561197960287930"
+	loc := Locale{Language: defaultLanguage, Country: "us", Currency: "USD"}
+
+	if _, err := h.trackPortfolio(steamID, "753", "6", loc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := h.snapshotPortfolio(steamID, "753", "6"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var portfolio Portfolio
+	if ok, _ := h.client.Store.Get(portfolioKey(steamID, "753", "6"), &portfolio); !ok || len(portfolio.Snapshots) != 1 {
+		t.Fatalf("expected a stored snapshot, got %+v", portfolio)
+	}
+	snapshot := portfolio.Snapshots[0]
+	if snapshot.Value != 20 || snapshot.ValueAfterFees != 16 || len(snapshot.Items) != 2 {
+		t.Errorf("unexpected snapshot %+v", snapshot)
+	}
+
+	// The series stays in the currency it started with
+	loc.Currency = "EUR"
+	if _, err := h.trackPortfolio(steamID, "753", "6", loc); !errors.Is(err, errInvalidRequest) {
+		t.Errorf("expected an invalid request for another currency, got %v", err)
+	}
+}
+
+func TestNewPortfolioSnapshot(t *testing.T) {
+	price := 150
+	valuation := InventoryValuation{Items: []ItemValuation{
+		{AppID: 730, MarketHashName: "Revolution Case", Count: 2, Price: &price},
+		{AppID: 730, MarketHashName: "Fracture Case", Count: 1, Pending: true},
+		{AppID: 730, MarketHashName: "Dreams & Nightmares Case", Count: 4, Pending: true},
+	}}
+	previous := &PortfolioSnapshot{Items: []PortfolioItem{
+		{AppID: 730, MarketHashName: "Fracture Case", Count: 1, Price: 40},
+	}}
+
+	snapshot := newPortfolioSnapshot(valuation, previous, time.Now())
+	if len(snapshot.Items) != 3 || snapshot.Complete {
+		t.Fatalf("expected an incomplete snapshot of every item, got %+v", snapshot)
+	}
+	if item := snapshot.Items[1]; !item.Stale || item.Price != 40 {
+		t.Errorf("expected the previous price to be kept, got %+v", item)
+	}
+	if item := snapshot.Items[2]; !item.Unpriced || item.Price != 0 {
+		t.Errorf("expected the never priced item to be listed as unpriced, got %+v", item)
+	}
+	if snapshot.Value != 340 || snapshot.ValueAfterFees != 2*131+36 {
+		t.Errorf("unexpected values %d, %d after fees", snapshot.Value, snapshot.ValueAfterFees)
+	}
+}
+
+func TestSnapshotPortfolioRotates(t *testing.T) {
+	h := newReplayHandlers(t)
+	loc := Locale{Language: defaultLanguage, Country: "us", Currency: "USD"}
+	if _, err := h.trackPortfolio(testSteamID, "753", "6", loc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// One reprice a minute can't cover both cards in a snapshot
+	h.reprices = newRepriceBudget(1)
+	wait := snapshotRoundWait
+	snapshotRoundWait = 0
+	t.Cleanup(func() { snapshotRoundWait = wait })
+
+	snapshot := func() PortfolioSnapshot {
+		t.Helper()
+		h.client.Cache.ClearMemoryCache()
+		h.reprices.last = h.reprices.last.Add(-time.Minute)
+		if err := h.snapshotPortfolio(testSteamID, "753", "6"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var portfolio Portfolio
+		h.client.Store.Get(portfolioKey(testSteamID, "753", "6"), &portfolio)
+		return *lastSnapshot(portfolio)
+	}
+	byName := func(s PortfolioSnapshot) map[string]PortfolioItem {
+		items := make(map[string]PortfolioItem)
+		for _, item := range s.Items {
+			items[item.MarketHashName] = item
+		}
+		return items
+	}
+
+	first := snapshot()
+	if items := byName(first); first.Complete || items["620-Wheatley"].Price != 9 || !items["620-GLaDOS"].Unpriced {
+		t.Fatalf("expected only the first card to be priced, got %+v", first)
+	}
+
+	// The next snapshots price the card priced longest ago first
+	second := snapshot()
+	if items := byName(second); !second.Complete || items["620-GLaDOS"].Stale || !items["620-Wheatley"].Stale {
+		t.Fatalf("expected the unpriced card to be priced next, got %+v", second)
+	}
+	if second.Value != 20 {
+		t.Errorf("expected the value of both cards, got %d", second.Value)
+	}
+
+	third := snapshot()
+	if items := byName(third); items["620-Wheatley"].Stale || !items["620-GLaDOS"].Stale {
+		t.Errorf("expected the oldest price to be refreshed, got %+v", third)
+	}
+}
+
+func TestNewPortfolioReport(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	snapshot := func(age time.Duration, revolution, fracture int) PortfolioSnapshot {
+		return PortfolioSnapshot{
+			Time:  now.Add(-age),
+			Value: revolution*2 + fracture,
+			Items: []PortfolioItem{
+				{AppID: 730, MarketHashName: "Revolution Case", Count: 2, Price: revolution},
+				{AppID: 730, MarketHashName: "Fracture Case", Count: 1, Price: fracture},
+			},
+		}
+	}
+	portfolio := Portfolio{Currency: "USD", Snapshots: []PortfolioSnapshot{
+		snapshot(8*24*time.Hour, 100, 50),
+		snapshot(3*24*time.Hour, 120, 45),
+		snapshot(30*time.Hour, 110, 40),
+		snapshot(6*time.Hour, 130, 40),
+		snapshot(0, 140, 30),
+	}}
+
+	report := newPortfolioReport(portfolio, now.Add(-4*24*time.Hour))
+	if len(report.Series) != 4 || report.Current == nil || report.Current.Value != 310 || report.Current.Items != 3 {
+		t.Fatalf("unexpected series %+v, current %+v", report.Series, report.Current)
+	}
+
+	// Compared with the last snapshots at least a day and a week old
+	if report.Daily == nil || report.Daily.Before != 260 || report.Daily.Change != 50 {
+		t.Errorf("unexpected daily change %+v", report.Daily)
+	}
+	if report.Weekly == nil || report.Weekly.Before != 250 || report.Weekly.ChangePercent != 24 {
+		t.Errorf("unexpected weekly change %+v", report.Weekly)
+	}
+
+	// Movers are measured from the start of the window
+	if len(report.Gainers) != 1 || report.Gainers[0].MarketHashName != "Revolution Case" || report.Gainers[0].Change != 40 {
+		t.Errorf("unexpected gainers %+v", report.Gainers)
+	}
+	if len(report.Losers) != 1 || report.Losers[0].MarketHashName != "Fracture Case" || report.Losers[0].Change != -15 {
+		t.Errorf("unexpected losers %+v", report.Losers)
+	}
+
+	// Young portfolios have no daily or weekly change yet
+	young := newPortfolioReport(Portfolio{Snapshots: portfolio.Snapshots[3:]}, now.Add(-time.Hour))
+	if young.Daily != nil || young.Weekly != nil {
+		t.Errorf("expected no changes, got %+v and %+v", young.Daily, young.Weekly)
+	}
+}
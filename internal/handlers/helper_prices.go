@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+const (
+	// appdetails only accepts several appids together with the
+	// price_overview filter, and long lists start failing past this size
+	priceBatchSize = 100
+
+	defaultCountryCode = "us"
+)
+
+// fetchPrices returns the store price of every app for the given country,
+// asking for many apps per call.
+func (h *SteamHandlers) fetchPrices(appIDs []int, cc string) (map[int]StorePrice, error) {
+	prices := make(map[int]StorePrice, len(appIDs))
+	for start := 0; start < len(appIDs); start += priceBatchSize {
+		end := min(start+priceBatchSize, len(appIDs))
+
+		ids := make([]string, 0, end-start)
+		for _, appID := range appIDs[start:end] {
+			ids = append(ids, strconv.Itoa(appID))
+		}
+
+		url := fmt.Sprintf("https://store.steampowered.com/api/appdetails?appids=%s&filters=price_overview&cc=%s",
+			strings.Join(ids, ","), cc)
+
+		bodyBytes, err := h.getResponseBody(url)
+		if err != nil {
+			return nil, err
+		}
+
+		batch, err := parsePrices(bodyBytes, cc)
+		if err != nil {
+			return nil, err
+		}
+		for appID, price := range batch {
+			prices[appID] = price
+		}
+	}
+
+	return prices, nil
+}
+
+// parsePrices decodes a filtered appdetails response. Steam sends an empty
+// array instead of an object as data when the app has no price, which is the
+// case for free games and apps not sold in the country.
+func parsePrices(body []byte, cc string) (map[int]StorePrice, error) {
+	var response map[string]struct {
+		Success bool            `json:"success"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("decoding prices: %w", err)
+	}
+
+	prices := make(map[int]StorePrice, len(response))
+	for id, entry := range response {
+		appID, err := strconv.Atoi(id)
+		if err != nil || !entry.Success {
+			continue
+		}
+
+		price := StorePrice{
+			AppID:       appID,
+			CountryCode: cc,
+		}
+
+		if bytes.HasPrefix(bytes.TrimSpace(entry.Data), []byte("{")) {
+			var data struct {
+				PriceOverview *steam.PriceOverview `json:"price_overview"`
+			}
+			if err := json.Unmarshal(entry.Data, &data); err != nil {
+				return nil, fmt.Errorf("decoding price of %d: %w", appID, err)
+			}
+			if overview := data.PriceOverview; overview != nil {
+				price.Priced = true
+				price.Currency = overview.Currency
+				price.Initial = overview.Initial
+				price.Final = overview.Final
+				price.DiscountPercent = overview.DiscountPercent
+				price.Savings = overview.Initial - overview.Final
+				price.InitialFormatted = overview.InitialFormatted
+				price.FinalFormatted = overview.FinalFormatted
+			}
+		}
+
+		prices[appID] = price
+	}
+
+	return prices, nil
+}
+
+func validCountryCode(cc string) (string, error) {
+	cc = strings.ToLower(strings.TrimSpace(cc))
+	if len(cc) != 2 || cc[0] < 'a' || cc[0] > 'z' || cc[1] < 'a' || cc[1] > 'z' {
+		return "", fmt.Errorf("invalid country code %q", cc)
+	}
+	return cc, nil
+}
+
+func userPrefsKey(steamID string) string {
+	return "prefs:" + steamID
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/masintxi/gamehub/internal/currency"
+)
+
+func TestParsePrices(t *testing.T) {
+	cases := []struct {
+		name   string
+		body   string
+		appID  int
+		want   StorePrice
+		absent bool
+	}{
+		{
+			name:  "priced",
+			body:  `{"620": {"success": true, "data": {"price_overview": {"currency": "EUR", "initial": 975, "final": 195, "discount_percent": 80, "initial_formatted": "9,75€", "final_formatted": "1,95€"}}}}`,
+			appID: 620,
+			want: StorePrice{AppID: 620, CountryCode: "es", Priced: true, Currency: "EUR", Initial: 975, Final: 195,
+				DiscountPercent: 80, Savings: 780, InitialFormatted: "9,75€", FinalFormatted: "1,95€"},
+		},
+		{
+			name:  "free games send an empty array",
+			body:  `{"570": {"success": true, "data": []}}`,
+			appID: 570,
+			want:  StorePrice{AppID: 570, CountryCode: "es"},
+		},
+		{
+			name:  "object without a price",
+			body:  `{"480": {"success": true, "data": {}}}`,
+			appID: 480,
+			want:  StorePrice{AppID: 480, CountryCode: "es"},
+		},
+		{
+			name:   "unknown apps are left out",
+			body:   `{"1": {"success": false}}`,
+			appID:  1,
+			absent: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			prices, err := parsePrices([]byte(c.body), "es")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			price, ok := prices[c.appID]
+			if c.absent {
+				if ok {
+					t.Errorf("expected no price, got %+v", price)
+				}
+				return
+			}
+			if !ok || price != c.want {
+				t.Errorf("expected %+v, got %+v", c.want, price)
+			}
+		})
+	}
+
+	if _, err := parsePrices([]byte(`{"620": {"success": true, "data": {"price_overview": "n/a"}}}`), "es"); err == nil {
+		t.Errorf("expected an error for a malformed price")
+	}
+}
+
+func TestHandlePricesTooManyAppIDs(t *testing.T) {
+	h := newReplayHandlers(t)
+
+	ids := make([]string, maxPriceAppIDs+1)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i + 1)
+	}
+
+	rec := httptest.NewRecorder()
+	h.HandlePrices(rec, httptest.NewRequest("GET", "/prices?appids="+strings.Join(ids, ","), nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if requests := h.client.Metrics.Snapshot()["store.steampowered.com"].Requests; requests != 0 {
+		t.Errorf("expected no upstream request, got %d", requests)
+	}
+}
+
+func TestHandleRegionalPricesTooManyCountries(t *testing.T) {
+	h := newReplayHandlers(t)
+	h.rates = &currency.Rates{Base: "USD", Rates: map[string]float64{"USD": 1}}
+	router := chi.NewRouter()
+	router.Get("/games/{appid}/prices", h.HandleRegionalPrices)
+
+	countries := make([]string, maxRegionalCountries+1)
+	for i := range countries {
+		countries[i] = "us"
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/games/620/prices?countries="+strings.Join(countries, ","), nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if requests := h.client.Metrics.Snapshot()["store.steampowered.com"].Requests; requests != 0 {
+		t.Errorf("expected no upstream request, got %d", requests)
+	}
+}
@@ -1,43 +1,86 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/masintxi/gamehub/internal/client"
 )
 
-func (h *SteamHandlers) getResponseBody(url string, headers map[string]string) ([]byte, error) {
+func (h *SteamHandlers) getResponseBody(url string) ([]byte, error) {
+	return h.getResponseBodyWithTTL(url, 0)
+}
+
+// getResponseBodyWithTTL caches the body for ttl instead of the default
+// expiry of the cache.
+func (h *SteamHandlers) getResponseBodyWithTTL(url string, ttl time.Duration) ([]byte, error) {
 	// Try to get the data from the cache first
 	val, ok := h.client.Cache.Get(url)
 	if ok {
 		return val, nil
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	body, err := h.fetchResponseBody(url)
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+		return nil, err
 	}
 
-	for key, value := range headers {
-		req.Header.Add(key, value)
+	h.client.Cache.AddWithTTL(url, []byte(string(body)), ttl)
+
+	return body, nil
+}
+
+// fetchResponseBody always goes to Steam, for data that must be fresh. The
+// client transport adds the headers, cookies and rate limits of each host.
+func (h *SteamHandlers) fetchResponseBody(rawURL string) ([]byte, error) {
+	req, err := http.NewRequest("GET", rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
 	resp, err := h.client.HttpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("fetching data: %w", err)
+		// The error repeats the URL, API key included
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL = client.RedactURL(req.URL)
+		}
+		return nil, fmt.Errorf("%w: %v", errUpstreamDown, err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
+		return nil, fmt.Errorf("%w: reading response: %v", errUpstreamDown, err)
 	}
 
-	h.client.Cache.Add(url, []byte(string(body)))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, statusError(req, resp.StatusCode, body)
+	}
+	if err := bodyError(body); err != nil {
+		return nil, err
+	}
 
 	return body, nil
 }
+
+func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("Error marshalling response: %v", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if _, err := w.Write(data); err != nil {
+		log.Printf("Error writing response: %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// Market fees, as a percentage of what the seller receives. Steam and
+	// the publisher take at least one cent each.
+	steamFeePercent     = 5
+	publisherFeePercent = 10
+
+	// Steam allows about twenty price overviews a minute. Every valuation,
+	// requests and portfolio snapshots alike, draws from one shared budget.
+	valuationRepricesPerMinute = 20
+)
+
+// repriceBudget is a token bucket of price overview requests, refilled
+// evenly over the minute.
+type repriceBudget struct {
+	mu        sync.Mutex
+	perMinute int
+	tokens    int
+	last      time.Time
+}
+
+func newRepriceBudget(perMinute int) *repriceBudget {
+	return &repriceBudget{perMinute: perMinute, tokens: perMinute, last: time.Now()}
+}
+
+// take spends a token, or returns false when the budget of the minute is
+// used up.
+func (b *repriceBudget) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if refill := int(now.Sub(b.last) * time.Duration(b.perMinute) / time.Minute); refill > 0 {
+		b.tokens = min(b.tokens+refill, b.perMinute)
+		b.last = b.last.Add(time.Duration(refill) * time.Minute / time.Duration(b.perMinute))
+		if b.tokens == b.perMinute {
+			b.last = now
+		}
+	}
+
+	if b.tokens == 0 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// valueInventory groups the marketable items of an inventory by market hash
+// name and prices every group with its price overview. Items that aren't
+// cached yet are left pending once the shared budget runs out; overviews
+// are cached, so calling it again resumes with them.
+func (h *SteamHandlers) valueInventory(items []InventoryItem, loc Locale) InventoryValuation {
+	return h.priceInventoryGroups(groupInventoryValues(items), loc)
+}
+
+// priceInventoryGroups prices the groups in the given order, so the first
+// ones get the budget when it can't cover them all.
+func (h *SteamHandlers) priceInventoryGroups(groups []ItemValuation, loc Locale) InventoryValuation {
+	code, _ := marketCurrency(loc)
+	valuation := InventoryValuation{
+		Currency: code,
+		Complete: true,
+		Items:    groups,
+	}
+
+	stopped := false
+	for i := range valuation.Items {
+		item := &valuation.Items[i]
+		valuation.TotalItems += item.Count
+
+		if !h.priceOverviewCached(item.AppID, item.MarketHashName, loc) && (stopped || !h.reprices.take()) {
+			item.Pending = true
+			valuation.Complete = false
+			continue
+		}
+
+		overview, err := h.fetchPriceOverview(item.AppID, item.MarketHashName, loc)
+		if errors.Is(err, errRateLimited) || errors.Is(err, errUpstreamDown) {
+			log.Printf("Stopping inventory valuation: %v", err)
+			stopped = true
+			item.Pending = true
+			valuation.Complete = false
+			continue
+		}
+		if err != nil {
+			item.Error = err.Error()
+			continue
+		}
+
+		// Items without listings keep the median of their last sales
+		price := overview.LowestPrice
+		if price == nil {
+			price = overview.MedianPrice
+		}
+		if price == nil {
+			item.Error = "no recent listings or sales"
+			continue
+		}
+		item.setPrice(*price)
+
+		valuation.PricedItems += item.Count
+		valuation.Value += item.Value
+		valuation.ValueAfterFees += item.ValueAfterFees
+		valuation.OnHoldValue += item.OnHold * *price
+	}
+
+	sort.SliceStable(valuation.Items, func(i, j int) bool {
+		return valuation.Items[i].Value > valuation.Items[j].Value
+	})
+
+	return valuation
+}
+
+// groupInventoryValues counts the marketable items of each market hash name.
+// Items under a trade hold still count, but can't be sold until it ends.
+func groupInventoryValues(items []InventoryItem) []ItemValuation {
+	groups := []ItemValuation{}
+	index := make(map[string]int)
+	for _, item := range items {
+		if !item.Marketable || item.MarketHashName == "" {
+			continue
+		}
+		amount := max(item.Amount, 1)
+
+		key := marketItemKey(item.AppID, item.MarketHashName)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, ItemValuation{
+				AppID:          item.AppID,
+				MarketHashName: item.MarketHashName,
+				Name:           item.Name,
+			})
+		}
+
+		groups[i].Count += amount
+		if !item.Tradable && item.TradeHoldDays > 0 {
+			groups[i].OnHold += amount
+			groups[i].TradeHoldDays = max(groups[i].TradeHoldDays, item.TradeHoldDays)
+		}
+	}
+
+	return groups
+}
+
+func marketItemKey(appID int, marketHashName string) string {
+	return fmt.Sprintf("%d:%s", appID, marketHashName)
+}
+
+func (item *ItemValuation) setPrice(price int) {
+	received := sellerReceives(price)
+	item.Price = &price
+	item.PriceAfterFees = &received
+	item.Value = price * item.Count
+	item.ValueAfterFees = received * item.Count
+}
+
+// marketFee returns what buyers pay on top of what the seller receives.
+func marketFee(received int) int {
+	return max(received*steamFeePercent/100, 1) + max(received*publisherFeePercent/100, 1)
+}
+
+// sellerReceives returns what is left of a buyer price after the fees, the
+// largest amount that doesn't go over the price once they are added.
+func sellerReceives(price int) int {
+	received := price * 100 / (100 + steamFeePercent + publisherFeePercent)
+	for received+1+marketFee(received+1) <= price {
+		received++
+	}
+	for received > 0 && received+marketFee(received) > price {
+		received--
+	}
+	return received
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+func TestGroupInventoryValues(t *testing.T) {
+	items := []InventoryItem{
+		{InventoryItem: steam.InventoryItem{AppID: 730, MarketHashName: "Revolution Case", Name: "Revolution Case", Amount: 1, Marketable: true, Tradable: true}},
+		{InventoryItem: steam.InventoryItem{AppID: 730, MarketHashName: "Revolution Case", Name: "Revolution Case", Amount: 1, Marketable: true, TradeHoldDays: 7}},
+		{InventoryItem: steam.InventoryItem{AppID: 753, MarketHashName: "620-Wheatley", Name: "Wheatley", Amount: 3, Marketable: true, Tradable: true}},
+		{InventoryItem: steam.InventoryItem{AppID: 753, MarketHashName: "753-Sack of Gems", Name: "Sack of Gems", Amount: 2, Tradable: true}},
+	}
+
+	groups := groupInventoryValues(items)
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 marketable groups, got %+v", groups)
+	}
+	if groups[0].Count != 2 || groups[0].OnHold != 1 || groups[0].TradeHoldDays != 7 {
+		t.Errorf("unexpected case group %+v", groups[0])
+	}
+	if groups[1].Count != 3 || groups[1].OnHold != 0 {
+		t.Errorf("expected stacks to be counted, got %+v", groups[1])
+	}
+}
+
+func TestSellerReceives(t *testing.T) {
+	cases := []struct {
+		price, want int
+	}{
+		{3, 1},
+		{9, 7},
+		{11, 9},
+		{115, 100},
+		{3150, 2739},
+	}
+	for _, c := range cases {
+		if got := sellerReceives(c.price); got != c.want {
+			t.Errorf("sellerReceives(%d) = %d, want %d", c.price, got, c.want)
+		}
+	}
+}
+
+func TestRepriceBudget(t *testing.T) {
+	budget := newRepriceBudget(2)
+	if !budget.take() || !budget.take() || budget.take() {
+		t.Fatal("expected the budget of the minute to run out after two reprices")
+	}
+
+	// Tokens come back evenly over the minute, up to the budget
+	budget.last = budget.last.Add(-30 * time.Second)
+	if !budget.take() || budget.take() {
+		t.Error("expected one token back after half a minute")
+	}
+	budget.last = budget.last.Add(-time.Hour)
+	if !budget.take() || !budget.take() || budget.take() {
+		t.Error("expected the tokens to stop at the budget")
+	}
+}
+
+func TestValueInventory(t *testing.T) {
+	h := newReplayHandlers(t)
+	loc := Locale{Language: defaultLanguage, Country: "us", Currency: "USD"}
+
+	items, _, err := h.fetchInventory("76561197960287930", "753", "6", loc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Only one item is repriced a minute here, the other waits
+	h.reprices = newRepriceBudget(1)
+	valuation := h.valueInventory(items, loc)
+	if valuation.Complete || valuation.TotalItems != 2 || valuation.PricedItems != 1 {
+		t.Fatalf("expected a partial valuation, got %+v", valuation)
+	}
+
+	// A minute later the next call reads the first price from the cache and
+	// prices the rest
+	h.reprices.last = h.reprices.last.Add(-time.Minute)
+	valuation = h.valueInventory(items, loc)
+	if !valuation.Complete || valuation.PricedItems != 2 {
+		t.Fatalf("expected a complete valuation, got %+v", valuation)
+	}
+	if valuation.Value != 20 || valuation.ValueAfterFees != 16 {
+		t.Errorf("unexpected totals %d, %d after fees", valuation.Value, valuation.ValueAfterFees)
+	}
+
+	// Items without listings are priced at their median
+	glados, wheatley := valuation.Items[0], valuation.Items[1]
+	if glados.MarketHashName != "620-GLaDOS" || *glados.Price != 11 || *glados.PriceAfterFees != 9 {
+		t.Errorf("unexpected item %+v", glados)
+	}
+	if wheatley.MarketHashName != "620-Wheatley" || *wheatley.Price != 9 {
+		t.Errorf("unexpected item %+v", wheatley)
+	}
+
+	if requests := h.client.Metrics.Snapshot()["steamcommunity.com"].Requests; requests != 4 {
+		t.Errorf("expected 4 upstream requests, got %d", requests)
+	}
+}
@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+const (
+	wishlistKeyPrefix = "wishlist:"
+
+	// Alerts kept per wishlist, oldest are dropped first
+	maxWishlistAlerts = 100
+)
+
+// fetchWishlist returns the wishlist of the user. Steam answers the same
+// empty response to empty and private wishlists, both end in
+// errPrivateProfile so an import never wipes the stored one.
+func (h *SteamHandlers) fetchWishlist(steamID string) ([]WishlistItem, error) {
+	url := fmt.Sprintf("https://api.steampowered.com/IWishlistService/GetWishlist/v1/?key=%s&steamid=%s",
+		h.steamAuth.GetAPIKey(), steamID)
+
+	bodyBytes, err := h.fetchResponseBody(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var wishlistResponse steam.WishlistResponse
+	if err := decodeSteam(bodyBytes, &wishlistResponse); err != nil {
+		return nil, fmt.Errorf("decoding wishlist: %w", err)
+	}
+
+	items := make([]WishlistItem, 0, len(wishlistResponse.Response.Items))
+	for _, item := range wishlistResponse.Response.Items {
+		wishlistItem := WishlistItem{
+			AppID:     item.AppID,
+			Priority:  item.Priority,
+			DateAdded: time.Unix(item.DateAdded, 0).UTC(),
+		}
+		if app, ok := h.client.Catalog.Lookup(item.AppID); ok {
+			wishlistItem.Name = app.Name
+		}
+		items = append(items, wishlistItem)
+	}
+
+	return items, nil
+}
+
+// importWishlist replaces the stored wishlist with the current one on Steam,
+// keeping the price targets already set for the games still wishlisted.
+func (h *SteamHandlers) importWishlist(steamID string) (Wishlist, error) {
+	items, err := h.fetchWishlist(steamID)
+	if err != nil {
+		return Wishlist{}, err
+	}
+
+	cc := h.userLocale(steamID).Country
+
+	var wishlist Wishlist
+	err = h.client.Store.Update(wishlistKey(steamID), &wishlist, func() error {
+		previous := make(map[int]WishlistItem, len(wishlist.Items))
+		for _, item := range wishlist.Items {
+			previous[item.AppID] = item
+		}
+
+		for i, item := range items {
+			if old, ok := previous[item.AppID]; ok {
+				items[i].TargetPrice = old.TargetPrice
+				items[i].Price = old.Price
+				items[i].ComingSoon = old.ComingSoon
+				items[i].ReleaseDate = old.ReleaseDate
+				items[i].LastChecked = old.LastChecked
+				if items[i].Name == "" {
+					items[i].Name = old.Name
+				}
+			}
+		}
+
+		wishlist.SteamID = steamID
+		wishlist.CountryCode = cc
+		wishlist.ImportedAt = time.Now().UTC()
+		wishlist.Items = items
+		return nil
+	})
+	if err != nil {
+		return Wishlist{}, err
+	}
+
+	return wishlist, nil
+}
+
+// checkWishlist refreshes prices and release dates, records the price
+// history and raises alerts for sales, reached targets, new historical lows
+// and releases.
+func (h *SteamHandlers) checkWishlist(steamID string) error {
+	var wishlist Wishlist
+	ok, err := h.client.Store.Get(wishlistKey(steamID), &wishlist)
+	if err != nil || !ok {
+		return err
+	}
+
+	appIDs := make([]int, len(wishlist.Items))
+	for i, item := range wishlist.Items {
+		appIDs[i] = item.AppID
+	}
+
+	prices, err := h.fetchPrices(appIDs, wishlist.CountryCode)
+	if err != nil {
+		return err
+	}
+
+	// Release dates need the full appdetails, so only unreleased games ask
+	loc := h.userLocale(steamID)
+	loc.Country = wishlist.CountryCode
+	releases := map[int]steam.GameData{}
+	for _, item := range wishlist.Items {
+		if item.LastChecked.IsZero() || item.ComingSoon {
+			gameData := h.GetGameData(strconv.Itoa(item.AppID), loc)
+			if gameData.Success {
+				releases[item.AppID] = gameData
+			}
+		}
+	}
+
+	// Record the histories first, the store is locked while the wishlist updates
+	now := time.Now().UTC()
+	check := wishlistCheck{
+		prices:   prices,
+		releases: releases,
+		lows:     make(map[int]PricePoint, len(prices)),
+	}
+	for appID, price := range prices {
+		if price.Priced {
+			if low, ok := h.recordPrice(wishlist.CountryCode, price, now); ok {
+				check.lows[appID] = low
+			}
+		}
+	}
+
+	// The check took a while, so apply it to the wishlist as it is now. The
+	// user may have changed targets or imported it again in between.
+	var current Wishlist
+	return h.client.Store.Update(wishlistKey(steamID), &current, func() error {
+		if current.SteamID == "" {
+			return fmt.Errorf("%w: wishlist no longer stored", errNotFound)
+		}
+		current.applyCheck(check, now)
+		return nil
+	})
+}
+
+// wishlistCheck holds what Steam answered for the games of a wishlist, and
+// the lowest price each one had before this check.
+type wishlistCheck struct {
+	prices   map[int]StorePrice
+	releases map[int]steam.GameData
+	lows     map[int]PricePoint
+}
+
+// applyCheck updates the prices and release dates of the wishlist, raising
+// alerts for the changes. Nothing is raised on the first check of a game but
+// reached targets, since there is nothing to compare with.
+func (wl *Wishlist) applyCheck(check wishlistCheck, now time.Time) {
+	for i := range wl.Items {
+		item := &wl.Items[i]
+		firstCheck := item.LastChecked.IsZero()
+
+		if gameData, ok := check.releases[item.AppID]; ok {
+			if item.Name == "" {
+				item.Name = gameData.Data.Name
+			}
+			wasComingSoon := item.ComingSoon
+			item.ComingSoon = gameData.Data.ReleaseDate.ComingSoon
+			item.ReleaseDate = gameData.Data.ReleaseDate.Date
+			if !firstCheck && wasComingSoon && !item.ComingSoon {
+				wl.addAlert(*item, "released", now)
+			}
+		}
+
+		if price, ok := check.prices[item.AppID]; ok && price.Priced {
+			previous := item.Price
+
+			if !firstCheck && previous != nil {
+				if previous.DiscountPercent == 0 && price.DiscountPercent > 0 {
+					wl.addAlert(*item, "on_sale", now)
+				}
+				if low, ok := check.lows[item.AppID]; ok && price.Final < low.Final {
+					wl.addAlert(*item, "historical_low", now)
+				}
+			}
+			if item.TargetPrice > 0 && price.Final <= item.TargetPrice &&
+				(previous == nil || previous.Final > item.TargetPrice) {
+				wl.addAlert(*item, "target_reached", now)
+			}
+
+			price.Name = item.Name
+			item.Price = &price
+		}
+
+		item.LastChecked = now
+	}
+}
+
+// checkWishlists is the background job going over every stored wishlist.
+func (h *SteamHandlers) checkWishlists() {
+	for _, key := range h.client.Store.Keys(wishlistKeyPrefix) {
+		steamID := strings.TrimPrefix(key, wishlistKeyPrefix)
+		if err := h.checkWishlist(steamID); err != nil {
+			log.Printf("Error checking wishlist of %s: %v", steamID, err)
+		}
+	}
+}
+
+// recordPrice appends the price to the app history when it changed and
+// returns the lowest price seen before it.
+func (h *SteamHandlers) recordPrice(cc string, price StorePrice, now time.Time) (PricePoint, bool) {
+	var (
+		history []PricePoint
+		low     PricePoint
+		hasLow  bool
+	)
+
+	err := h.client.Store.Update(priceHistoryKey(cc, price.AppID), &history, func() error {
+		for _, point := range history {
+			if !hasLow || point.Final < low.Final {
+				low = point
+				hasLow = true
+			}
+		}
+
+		if n := len(history); n > 0 && history[n-1].Final == price.Final && history[n-1].Currency == price.Currency {
+			return nil
+		}
+		history = append(history, PricePoint{
+			Time:            now,
+			Currency:        price.Currency,
+			Initial:         price.Initial,
+			Final:           price.Final,
+			DiscountPercent: price.DiscountPercent,
+		})
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error saving price history of %d: %v", price.AppID, err)
+	}
+
+	return low, hasLow
+}
+
+func (h *SteamHandlers) priceHistory(cc string, appID int) []PricePoint {
+	history := []PricePoint{}
+	if _, err := h.client.Store.Get(priceHistoryKey(cc, appID), &history); err != nil {
+		log.Printf("Error reading price history of %d: %v", appID, err)
+	}
+	return history
+}
+
+func (wl *Wishlist) addAlert(item WishlistItem, kind string, now time.Time) {
+	wl.Alerts = append(wl.Alerts, WishlistAlert{
+		AppID: item.AppID,
+		Name:  item.Name,
+		Kind:  kind,
+		Time:  now,
+	})
+	if len(wl.Alerts) > maxWishlistAlerts {
+		wl.Alerts = wl.Alerts[len(wl.Alerts)-maxWishlistAlerts:]
+	}
+}
+
+func wishlistKey(steamID string) string {
+	return wishlistKeyPrefix + steamID
+}
+
+func priceHistoryKey(cc string, appID int) string {
+	return fmt.Sprintf("pricehistory:%s:%d", cc, appID)
+}
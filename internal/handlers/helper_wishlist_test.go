@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/masintxi/gamehub/internal/steam"
+)
+
+func TestWishlistApplyCheck(t *testing.T) {
+	lastCheck := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := lastCheck.Add(6 * time.Hour)
+	priced := func(final, discount int) *StorePrice {
+		return &StorePrice{AppID: 620, Priced: true, Currency: "EUR", Initial: 975, Final: final, DiscountPercent: discount}
+	}
+	released := func(comingSoon bool) steam.GameData {
+		var data steam.GameData
+		data.Success = true
+		data.Data.Name = "Portal 2"
+		data.Data.ReleaseDate.ComingSoon = comingSoon
+		return data
+	}
+
+	cases := []struct {
+		name    string
+		item    WishlistItem
+		price   *StorePrice
+		low     *PricePoint
+		release *steam.GameData
+		want    []string
+	}{
+		{
+			name:  "sale starts",
+			item:  WishlistItem{Price: priced(975, 0), LastChecked: lastCheck},
+			price: priced(487, 50),
+			want:  []string{"on_sale"},
+		},
+		{
+			name:  "sale goes on",
+			item:  WishlistItem{Price: priced(487, 50), LastChecked: lastCheck},
+			price: priced(487, 50),
+		},
+		{
+			name:  "new historical low",
+			item:  WishlistItem{Price: priced(487, 50), LastChecked: lastCheck},
+			price: priced(195, 80),
+			low:   &PricePoint{Final: 487},
+			want:  []string{"historical_low"},
+		},
+		{
+			name:  "same as the historical low",
+			item:  WishlistItem{Price: priced(975, 0), LastChecked: lastCheck},
+			price: priced(975, 0),
+			low:   &PricePoint{Final: 975},
+		},
+		{
+			name:  "target reached",
+			item:  WishlistItem{TargetPrice: 500, Price: priced(975, 0), LastChecked: lastCheck},
+			price: priced(487, 50),
+			want:  []string{"on_sale", "target_reached"},
+		},
+		{
+			name:  "target already reached",
+			item:  WishlistItem{TargetPrice: 500, Price: priced(487, 50), LastChecked: lastCheck},
+			price: priced(450, 55),
+		},
+		{
+			name:  "first check only raises targets",
+			item:  WishlistItem{TargetPrice: 500},
+			price: priced(195, 80),
+			low:   &PricePoint{Final: 487},
+			want:  []string{"target_reached"},
+		},
+		{
+			name:    "release",
+			item:    WishlistItem{ComingSoon: true, LastChecked: lastCheck},
+			release: func() *steam.GameData { d := released(false); return &d }(),
+			want:    []string{"released"},
+		},
+		{
+			name:    "still coming soon",
+			item:    WishlistItem{ComingSoon: true, LastChecked: lastCheck},
+			release: func() *steam.GameData { d := released(true); return &d }(),
+		},
+		{
+			name:    "released before the first check",
+			item:    WishlistItem{},
+			release: func() *steam.GameData { d := released(false); return &d }(),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.item.AppID = 620
+			wishlist := Wishlist{SteamID: testSteamID, Items: []WishlistItem{c.item}}
+			check := wishlistCheck{
+				prices:   map[int]StorePrice{},
+				releases: map[int]steam.GameData{},
+				lows:     map[int]PricePoint{},
+			}
+			if c.price != nil {
+				check.prices[620] = *c.price
+			}
+			if c.low != nil {
+				check.lows[620] = *c.low
+			}
+			if c.release != nil {
+				check.releases[620] = *c.release
+			}
+
+			wishlist.applyCheck(check, now)
+
+			kinds := []string{}
+			for _, alert := range wishlist.Alerts {
+				kinds = append(kinds, alert.Kind)
+			}
+			if !slices.Equal(kinds, c.want) {
+				t.Errorf("expected alerts %v, got %v", c.want, kinds)
+			}
+
+			item := wishlist.Items[0]
+			if !item.LastChecked.Equal(now) {
+				t.Errorf("expected the item to be marked as checked, got %v", item.LastChecked)
+			}
+			if c.price != nil && (item.Price == nil || item.Price.Final != c.price.Final) {
+				t.Errorf("expected the new price to be kept, got %+v", item.Price)
+			}
+		})
+	}
+}
+
+func TestReplayImportPrivateWishlistKeepsStored(t *testing.T) {
+	h := newReplayHandlers(t)
+	stored := Wishlist{
+		SteamID:     testSteamID,
+		CountryCode: "us",
+		Items:       []WishlistItem{{AppID: 620, Name: "Portal 2", TargetPrice: 199}},
+	}
+	if err := h.client.Store.Put(wishlistKey(testSteamID), stored); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	h.HandleImportWishlist(rec, authenticatedRequest(t, h, "/wishlist/import"))
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var wishlist Wishlist
+	if ok, err := h.client.Store.Get(wishlistKey(testSteamID), &wishlist); !ok || err != nil {
+		t.Fatalf("expected the stored wishlist, got ok=%v err=%v", ok, err)
+	}
+	if len(wishlist.Items) != 1 || wishlist.Items[0].TargetPrice != 199 {
+		t.Errorf("expected the stored items and targets to be kept, got %+v", wishlist.Items)
+	}
+}
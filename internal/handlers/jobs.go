@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"time"
+)
+
+type JobsConfig struct {
+	PlayerCountInterval    time.Duration // How often tracked games are sampled, 0 disables it
+	CatalogRefreshInterval time.Duration // How often the app catalog is imported, 0 disables it
+	WishlistCheckInterval  time.Duration // How often stored wishlists are checked, 0 disables it
+	PortfolioInterval      time.Duration // How often tracked portfolios are snapshotted, 0 disables it
+}
+
+func (h *SteamHandlers) startJobs(cfg JobsConfig) {
+	if cfg.PlayerCountInterval > 0 {
+		go runEvery(cfg.PlayerCountInterval, h.samplePlayerCounts)
+	}
+	if cfg.WishlistCheckInterval > 0 {
+		go runEvery(cfg.WishlistCheckInterval, h.checkWishlists)
+	}
+	if cfg.PortfolioInterval > 0 {
+		go runEvery(cfg.PortfolioInterval, h.snapshotPortfolios)
+	}
+	if cfg.CatalogRefreshInterval > 0 {
+		go func() {
+			// Import right away when the saved catalog is missing or stale
+			if time.Since(h.client.Catalog.UpdatedAt()) > cfg.CatalogRefreshInterval {
+				h.refreshCatalog()
+			}
+			runEvery(cfg.CatalogRefreshInterval, h.refreshCatalog)
+		}()
+	}
+}
+
+func runEvery(interval time.Duration, job func()) {
+	ticker := time.NewTicker(interval)
+	for range ticker.C {
+		job()
+	}
+}
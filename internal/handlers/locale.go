@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/masintxi/gamehub/internal/currency"
+)
+
+type localeKey struct{}
+
+// Locale is the language, store country and currency every Steam call of a
+// request is made with.
+type Locale struct {
+	Language string `json:"language"`
+	Country  string `json:"country"`
+	Currency string `json:"currency"`
+}
+
+const defaultLanguage = "english"
+
+// Steam language names, by the ISO 639-1 codes browsers send
+var steamLanguages = map[string]string{
+	"en": "english", "es": "spanish", "es-419": "latam", "fr": "french",
+	"de": "german", "it": "italian", "pt": "portuguese", "pt-br": "brazilian",
+	"ru": "russian", "pl": "polish", "tr": "turkish", "ja": "japanese",
+	"ko": "koreana", "zh-cn": "schinese", "zh": "schinese", "zh-tw": "tchinese",
+	"uk": "ukrainian", "cs": "czech", "da": "danish", "nl": "dutch",
+	"fi": "finnish", "el": "greek", "hu": "hungarian", "no": "norwegian",
+	"nb": "norwegian", "ro": "romanian", "sv": "swedish", "th": "thai",
+	"vi": "vietnamese", "bg": "bulgarian", "ar": "arabic", "id": "indonesian",
+}
+
+// LocaleMiddleware resolves the locale of the request and stores it in the
+// context. Every setting comes from, in order, the l/cc/currency query
+// parameters, the settings saved by the user and the Accept-Language header,
+// before falling back to the defaults.
+func (h *SteamHandlers) LocaleMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		loc := Locale{}
+		if steamID, err := h.steamAuth.GetSteamID(r); err == nil {
+			loc = h.savedLocale(steamID)
+		}
+
+		query := r.URL.Query()
+		if l := query.Get("l"); l != "" {
+			language, err := steamLanguage(l)
+			if err != nil {
+				respondWithError(w, r, errInvalidRequest, err.Error())
+				return
+			}
+			loc.Language = language
+		}
+		if cc := query.Get("cc"); cc != "" {
+			country, err := validCountryCode(cc)
+			if err != nil {
+				respondWithError(w, r, errInvalidRequest, err.Error())
+				return
+			}
+			loc.Country = country
+		}
+		if c := query.Get("currency"); c != "" {
+			code, err := validCurrency(c)
+			if err != nil {
+				respondWithError(w, r, errInvalidRequest, err.Error())
+				return
+			}
+			loc.Currency = code
+		}
+
+		if loc.Language == "" {
+			loc.Language = acceptLanguage(r.Header.Get("Accept-Language"))
+		}
+
+		ctx := context.WithValue(r.Context(), localeKey{}, withLocaleDefaults(loc))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func LocaleFromContext(ctx context.Context) Locale {
+	if loc, ok := ctx.Value(localeKey{}).(Locale); ok {
+		return loc
+	}
+	return withLocaleDefaults(Locale{})
+}
+
+// userLocale returns the settings saved by the user, completed with the
+// defaults. Background jobs use it since they have no request.
+func (h *SteamHandlers) userLocale(steamID string) Locale {
+	return withLocaleDefaults(h.savedLocale(steamID))
+}
+
+// savedLocale returns only the settings saved by the user, leaving the rest
+// empty so the request can still fill them.
+func (h *SteamHandlers) savedLocale(steamID string) Locale {
+	var prefs UserPrefs
+	if _, err := h.client.Store.Get(userPrefsKey(steamID), &prefs); err != nil {
+		return Locale{}
+	}
+	return Locale(prefs)
+}
+
+func withLocaleDefaults(loc Locale) Locale {
+	if loc.Language == "" {
+		loc.Language = defaultLanguage
+	}
+	if loc.Country == "" {
+		loc.Country = defaultCountryCode
+	}
+	if loc.Currency == "" {
+		loc.Currency = currency.ForCountry(loc.Country)
+	}
+	return loc
+}
+
+// steamLanguage accepts either a Steam language name or an ISO code.
+func steamLanguage(l string) (string, error) {
+	l = strings.ToLower(strings.TrimSpace(l))
+	if language, ok := steamLanguages[l]; ok {
+		return language, nil
+	}
+	for _, language := range steamLanguages {
+		if language == l {
+			return language, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported language %q", l)
+}
+
+func acceptLanguage(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		tag, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(tag)
+		if language, ok := steamLanguages[tag]; ok {
+			return language
+		}
+		base, _, _ := strings.Cut(tag, "-")
+		if language, ok := steamLanguages[base]; ok {
+			return language
+		}
+	}
+	return ""
+}
+
+func validCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !currency.IsSteamCurrency(code) {
+		return "", fmt.Errorf("unsupported currency %q", code)
+	}
+	return code, nil
+}
+
+func (h *SteamHandlers) HandleGetLocale(w http.ResponseWriter, r *http.Request) {
+	if _, err := h.steamAuth.GetSteamID(r); err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, LocaleFromContext(r.Context()))
+}
+
+// HandleSetLocale saves the l, cc and currency query parameters as the
+// defaults of the user. The middleware has already validated them.
+func (h *SteamHandlers) HandleSetLocale(w http.ResponseWriter, r *http.Request) {
+	steamID, err := h.steamAuth.GetSteamID(r)
+	if err != nil {
+		respondWithError(w, r, errUnauthenticated, "Not authenticated")
+		return
+	}
+
+	query := r.URL.Query()
+	loc := LocaleFromContext(r.Context())
+
+	var prefs UserPrefs
+	err = h.client.Store.Update(userPrefsKey(steamID), &prefs, func() error {
+		if query.Get("l") != "" {
+			prefs.Language = loc.Language
+		}
+		if query.Get("cc") != "" {
+			prefs.Country = loc.Country
+		}
+		if query.Get("currency") != "" {
+			prefs.Currency = loc.Currency
+		}
+		return nil
+	})
+	if err != nil {
+		respondWithError(w, r, err, "Failed to save locale")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, withLocaleDefaults(Locale(prefs)))
+}
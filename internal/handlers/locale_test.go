@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLocaleMiddleware(t *testing.T) {
+	h := newReplayHandlers(t)
+	handler := h.LocaleMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respondWithJSON(w, http.StatusOK, LocaleFromContext(r.Context()))
+	}))
+
+	cases := []struct {
+		name           string
+		target         string
+		saved          *UserPrefs // Logged in with these settings
+		acceptLanguage string
+		want           Locale
+	}{
+		{"defaults", "/", nil, "", Locale{Language: "english", Country: "us", Currency: "USD"}},
+		{"accept language", "/", nil, "es-ES,es;q=0.9", Locale{Language: "spanish", Country: "us", Currency: "USD"}},
+		{"currency from country", "/?cc=jp", nil, "", Locale{Language: "english", Country: "jp", Currency: "JPY"}},
+		{"saved over header", "/", &UserPrefs{Language: "french", Currency: "EUR"}, "es", Locale{Language: "french", Country: "us", Currency: "EUR"}},
+		{"header over default", "/", &UserPrefs{Currency: "EUR"}, "es", Locale{Language: "spanish", Country: "us", Currency: "EUR"}},
+		{"query over saved", "/?l=de&cc=DE&currency=gbp", &UserPrefs{Language: "french", Country: "fr", Currency: "EUR"}, "es", Locale{Language: "german", Country: "de", Currency: "GBP"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", c.target, nil)
+			if c.saved != nil {
+				if err := h.client.Store.Put(userPrefsKey(testSteamID), *c.saved); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				req = authenticatedRequest(t, h, c.target)
+			}
+			if c.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", c.acceptLanguage)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+
+			var got Locale
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != c.want {
+				t.Errorf("expected %+v, got %+v", c.want, got)
+			}
+		})
+	}
+}
+
+func TestLocaleMiddlewareInvalid(t *testing.T) {
+	h := newReplayHandlers(t)
+	handler := h.LocaleMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the request shouldn't reach the handler")
+	}))
+
+	for _, target := range []string{"/?l=klingon", "/?cc=usa", "/?currency=XYZ"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", target, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", target, rec.Code)
+		}
+	}
+}
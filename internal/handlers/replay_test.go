@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/masintxi/gamehub/internal/auth"
+	"github.com/masintxi/gamehub/internal/cache"
+	"github.com/masintxi/gamehub/internal/catalog"
+	"github.com/masintxi/gamehub/internal/client"
+	"github.com/masintxi/gamehub/internal/store"
+)
+
+const testSteamID = "76561197960287930"
+
+// newReplayHandlers returns handlers whose Steam calls are answered by the
+// fixtures of testdata/fixtures, with a cache and store of their own.
+func newReplayHandlers(t *testing.T) *SteamHandlers {
+	t.Helper()
+	dir := t.TempDir()
+
+	c := client.NewClient(
+		cache.CacheConfig{ProjectName: "gamehub-test", CachePath: dir},
+		store.StoreConfig{ProjectName: "gamehub-test", StorePath: dir},
+		catalog.CatalogConfig{ProjectName: "gamehub-test", CatalogPath: dir},
+		client.TransportConfig{
+			Mode:        client.ModeReplay,
+			FixturesDir: "testdata/fixtures",
+		},
+	)
+	steamAuth := auth.NewSteamAuth(auth.SteamAuth{ApiKey: "test-key", SteamClient: c.HttpClient, Jar: c.Jar})
+
+	return NewSteamHandlers(c, steamAuth, HandlersConfig{})
+}
+
+// authenticatedRequest returns a request carrying the session cookie of
+// testSteamID.
+func authenticatedRequest(t *testing.T, h *SteamHandlers, target string) *http.Request {
+	t.Helper()
+
+	req := httptest.NewRequest("GET", target, nil)
+	session, err := h.steamAuth.GetSession(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	session.Values["steamID"] = testSteamID
+
+	rec := httptest.NewRecorder()
+	if err := session.Save(req, rec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req = httptest.NewRequest("GET", target, nil)
+	for _, cookie := range rec.Result().Cookies() {
+		req.AddCookie(cookie)
+	}
+	return req
+}
+
+func TestReplayUserGames(t *testing.T) {
+	h := newReplayHandlers(t)
+	handler := h.LocaleMiddleware(http.HandlerFunc(h.HandleUserGames))
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, authenticatedRequest(t, h, "/user-games?sort=playtime"))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		var games []LibraryGame
+		if err := json.Unmarshal(rec.Body.Bytes(), &games); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(games) != 3 {
+			t.Fatalf("expected 3 games, got %d", len(games))
+		}
+		if games[0].Name != "Portal 2" || games[2].Name != "Portal" {
+			t.Errorf("expected games sorted by playtime, got %s, %s, %s", games[0].Name, games[1].Name, games[2].Name)
+		}
+		if games[2].LastPlayed != nil {
+			t.Errorf("expected a never played game to have no last played date")
+		}
+	}
+
+	// The second call must come from the cache
+	requests := h.client.Metrics.Snapshot()["api.steampowered.com"].Requests
+	if requests != 1 {
+		t.Errorf("expected 1 upstream request, got %d", requests)
+	}
+}
+
+func TestReplayInventory(t *testing.T) {
+	h := newReplayHandlers(t)
+	handler := h.LocaleMiddleware(http.HandlerFunc(h.HandleInventory))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, authenticatedRequest(t, h, "/inventory"))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var result InventoryResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.TotalInventoryCount != 3 || len(result.Items) != 3 {
+		t.Fatalf("expected 3 items over both pages, got %d of %d", len(result.Items), result.TotalInventoryCount)
+	}
+	if item := result.Items[2]; item.Name != "GLaDOS" || item.Category != "Trading Card" || item.Game != "Portal 2" {
+		t.Errorf("unexpected item from the second page: %+v", item)
+	}
+}
+
+func TestReplayInventoryEmpty(t *testing.T) {
+	h := newReplayHandlers(t)
+	router := chi.NewRouter()
+	router.With(h.LocaleMiddleware).Get("/inventory/{appid}/{contextid}", h.HandleInventory)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, authenticatedRequest(t, h, "/inventory/730/2"))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"items":[]`) {
+		t.Errorf("expected an empty list of items, got %s", rec.Body.String())
+	}
+}
+
+func TestReplayBadgesLocalized(t *testing.T) {
+	h := newReplayHandlers(t)
+	h.steamAuth.SessionID = "test-session"
+	h.steamAuth.SteamLoginSecure = testSteamID + "%7C%7Ctest-login"
+	handler := h.LocaleMiddleware(http.HandlerFunc(h.HandleBadges))
+
+	// The inventory and card set come in Spanish, the badge page in English
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, authenticatedRequest(t, h, "/badges?l=es"))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var overview BadgeOverview
+	if err := json.Unmarshal(rec.Body.Bytes(), &overview); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(overview.GameBadges) != 1 {
+		t.Fatalf("expected the badge of Portal 2, got %+v", overview.GameBadges)
+	}
+	badge := overview.GameBadges[0]
+	if badge.TotalCards != 2 || len(badge.CardsHeld) != 1 || badge.CardsHeld[0].Name != "Wheatley" {
+		t.Errorf("expected the foil card to be left out, got %+v", badge.CardsHeld)
+	}
+	if len(badge.CardsMissing) != 1 || badge.CardsMissing[0] != "GLaDOS" {
+		t.Errorf("unexpected missing cards %+v", badge.CardsMissing)
+	}
+	if badge.CardDropsRemaining == nil || *badge.CardDropsRemaining != 3 {
+		t.Errorf("expected 3 card drops remaining, got %v", badge.CardDropsRemaining)
+	}
+}
+
+func TestFetchCardDropsRemaining(t *testing.T) {
+	h := newReplayHandlers(t)
+	h.steamAuth.SessionID = "test-session"
+
+	cases := []struct {
+		name        string
+		loginSecure string
+		want        *int
+	}{
+		{"own session", testSteamID + "%7C%7Ctest-login", intPtr(3)},
+		{"session of another account", testFriendID + "%7C%7Ctest-login", nil},
+		{"no session", "", nil},
+	}
+	for _, c := range cases {
+		h.steamAuth.SteamLoginSecure = c.loginSecure
+		got := h.fetchCardDropsRemaining(testSteamID, 620)
+		if (got == nil) != (c.want == nil) || (got != nil && *got != *c.want) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.want, got)
+		}
+	}
+
+	// The page is read again every time, the drops change while playing
+	if requests := h.client.Metrics.Snapshot()["steamcommunity.com"].Requests; requests != 1 {
+		t.Errorf("expected 1 upstream request, got %d", requests)
+	}
+	h.steamAuth.SteamLoginSecure = testSteamID + "%7C%7Ctest-login"
+	h.fetchCardDropsRemaining(testSteamID, 620)
+	if requests := h.client.Metrics.Snapshot()["steamcommunity.com"].Requests; requests != 2 {
+		t.Errorf("expected the page to skip the cache, got %d requests", requests)
+	}
+}
+
+func TestReplayUnauthenticated(t *testing.T) {
+	h := newReplayHandlers(t)
+
+	handlers := map[string]http.HandlerFunc{
+		"user games":       h.HandleUserGames,
+		"market overview":  h.HandleMarketOverview,
+		"market orderbook": h.HandleMarketOrderBook,
+		"market listings":  h.HandleMarketListings,
+	}
+	for name, handler := range handlers {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest("GET", "/", nil))
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s: expected status 401, got %d", name, rec.Code)
+		}
+	}
+}
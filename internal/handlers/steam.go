@@ -1,18 +1,49 @@
 package handlers
 
 import (
+	"log"
+	"time"
+
 	"github.com/masintxi/gamehub/internal/auth"
 	"github.com/masintxi/gamehub/internal/client"
+	"github.com/masintxi/gamehub/internal/currency"
 )
 
 type SteamHandlers struct {
-	client    *client.Client
-	steamAuth *auth.SteamAuth
+	client          *client.Client
+	steamAuth       *auth.SteamAuth
+	rates           *currency.Rates
+	regionCountries []string
+	newsLimiter     *time.Ticker   // Shared by every news request
+	reprices        *repriceBudget // Shared by every inventory valuation
+	trackedPlayers  *trackedGames  // Games sampled by the player count job
+}
+
+type HandlersConfig struct {
+	Jobs              JobsConfig
+	RegionCountries   []string // Store countries compared by the regional prices
+	ExchangeRatesFile string   // Table used to convert regional prices
 }
 
-func NewSteamHandlers(client *client.Client, steamAuth *auth.SteamAuth) *SteamHandlers {
-	return &SteamHandlers{
-		client:    client,
-		steamAuth: steamAuth,
+func NewSteamHandlers(client *client.Client, steamAuth *auth.SteamAuth, cfg HandlersConfig) *SteamHandlers {
+	h := &SteamHandlers{
+		client:          client,
+		steamAuth:       steamAuth,
+		regionCountries: cfg.RegionCountries,
+		newsLimiter:     time.NewTicker(newsRequestInterval),
+		reprices:        newRepriceBudget(valuationRepricesPerMinute),
+		trackedPlayers:  newTrackedGames(client.Store.Keys(playerCountKeyPrefix), maxTrackedPlayerCounts),
 	}
+
+	if cfg.ExchangeRatesFile != "" {
+		rates, err := currency.LoadRates(cfg.ExchangeRatesFile)
+		if err != nil {
+			log.Printf("Warning: %v. Regional prices won't be converted", err)
+		}
+		h.rates = rates
+	}
+
+	h.startJobs(cfg.Jobs)
+
+	return h
 }
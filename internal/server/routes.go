@@ -15,17 +15,73 @@ const (
 )
 
 func (s *Server) SetupRoutes() {
+	// Only the routes calling Steam with a language, country or currency
+	// resolve the locale, so a bad l or currency can't break the others
+	withLocale := s.Router.With(s.Handlers.LocaleMiddleware)
+
 	s.Router.Get("/", s.HandleHome)
 	s.Router.Route("/auth", func(r chi.Router) {
 		r.Get(authPath, s.SteamAuth.HandleLogin)
 		r.Get(callbackPath, s.SteamAuth.HandleCallback)
 	})
-	s.Router.Get("/inventory", s.Handlers.HandleInventory)
+	withLocale.Get("/inventory", s.Handlers.HandleInventory)
+	withLocale.Get("/inventory/apps", s.Handlers.HandleInventoryApps)
+	withLocale.Get("/inventory/all", s.Handlers.HandleAllInventories)
+	withLocale.Get("/inventory/value", s.Handlers.HandleInventoryValue)
+	withLocale.Get("/inventory/{appid}/{contextid}", s.Handlers.HandleInventory)
+	withLocale.Get("/inventory/{appid}/{contextid}/value", s.Handlers.HandleInventoryValue)
 	s.Router.Get("/trade-inventory", s.Handlers.HandleTradeInventory)
-	s.Router.Get("/market/{market_hash_name}", s.Handlers.HandleMarketData)
-	s.Router.Get("/market/{item_name}", s.Handlers.HandleMarketItem)
+	s.Router.Get("/trade-inventory/{appid}/{contextid}", s.Handlers.HandleTradeInventory)
+	// Every market route but the public history needs a login
+	s.Router.Route("/market", func(r chi.Router) {
+		r.Post("/nameids", s.Handlers.HandleResolveNameIDs)
+		r.Route("/{appid}/{market_hash_name}", func(r chi.Router) {
+			r.Use(s.Handlers.LocaleMiddleware)
+			r.Get("/", s.Handlers.HandleMarketOverview)
+			r.Get("/orderbook", s.Handlers.HandleMarketOrderBook)
+			r.Get("/history", s.Handlers.HandleMarketHistory)
+			r.Get("/listings", s.Handlers.HandleMarketListings)
+		})
+	})
+	s.Router.Route("/portfolio", func(r chi.Router) {
+		r.Use(s.Handlers.LocaleMiddleware)
+		r.Get("/", s.Handlers.HandlePortfolio)
+		r.Post("/", s.Handlers.HandleTrackPortfolio)
+		r.Delete("/", s.Handlers.HandleUntrackPortfolio)
+		r.Get("/{appid}/{contextid}", s.Handlers.HandlePortfolio)
+		r.Post("/{appid}/{contextid}", s.Handlers.HandleTrackPortfolio)
+		r.Delete("/{appid}/{contextid}", s.Handlers.HandleUntrackPortfolio)
+	})
 	s.Router.Get("/user-data", s.Handlers.HandleUserData)
+	withLocale.Get("/user/badges", s.Handlers.HandleBadges)
+	s.Router.Get("/players/standing", s.Handlers.HandlePlayerStandings)
+	s.Router.Get("/players/{steamid}/standing", s.Handlers.HandlePlayerStanding)
 	s.Router.Get("/user-games", s.Handlers.HandleUserGames)
+	s.Router.Get("/user-games/recent", s.Handlers.HandleRecentGames)
+	s.Router.Get("/user-games/untouched", s.Handlers.HandleUntouchedGames)
+	withLocale.Get("/user-games/prices", s.Handlers.HandleLibraryPrices)
+	withLocale.Get("/prices", s.Handlers.HandlePrices)
+	s.Router.Route("/wishlist", func(r chi.Router) {
+		r.Get("/", s.Handlers.HandleWishlist)
+		r.Post("/import", s.Handlers.HandleImportWishlist)
+		r.Get("/alerts", s.Handlers.HandleWishlistAlerts)
+		r.Put("/{appid}/target", s.Handlers.HandleWishlistTarget)
+		r.With(s.Handlers.LocaleMiddleware).Get("/{appid}/history", s.Handlers.HandleWishlistHistory)
+	})
+	withLocale.Get("/user/locale", s.Handlers.HandleGetLocale)
+	withLocale.Put("/user/locale", s.Handlers.HandleSetLocale)
+	s.Router.Get("/friends", s.Handlers.HandleFriends)
+	s.Router.Get("/news", s.Handlers.HandleNews)
+	s.Router.Get("/games/search", s.Handlers.HandleGameSearch)
+	withLocale.Get("/games/together", s.Handlers.HandlePlayTogether)
+	withLocale.Get("/games/achievements", s.Handlers.HandleAchievementSummary)
+	withLocale.Get("/games/{appid}", s.Handlers.HandleGameData)
+	withLocale.Get("/games/{appid}/achievements", s.Handlers.HandleGameAchievements)
+	s.Router.Get("/games/{appid}/players", s.Handlers.HandleGamePlayers)
+	withLocale.Get("/games/{appid}/prices", s.Handlers.HandleRegionalPrices)
+	if s.Debug {
+		s.Router.Get("/debug/upstream", s.Handlers.HandleUpstreamMetrics)
+	}
 }
 
 func (s *Server) HandleHome(w http.ResponseWriter, r *http.Request) {
@@ -35,14 +91,42 @@ func (s *Server) HandleHome(w http.ResponseWriter, r *http.Request) {
                 <a href="/auth/steam">Login with Steam</a>
                 <br/>
                 <a href="/trade-inventory">View Inventory (Trading API)</a>
+				<br/>
+				<a href="/inventory/apps">View Inventory Apps</a>
+				<br/>
+				<a href="/inventory/730/2">View CS2 Inventory</a>
+				<br/>
+				<a href="/inventory/all">View All Inventories</a>
+				<br/>
+				<a href="/inventory/value">View Inventory Value</a>
+				<br/>
+				<a href="/portfolio">View Portfolio History</a>
 				<br/>
 				<a href="/user-data">View User Data</a>
 				<br/>
+				<a href="/user/badges">View Badges and Trading Cards</a>
+				<br/>
 				<a href="/user-games">View User Games</a>
 				<br/>
-				<a href="/market/311690-Frifle and Mauser">Market Price for Frifle and Mauser</a>
+				<a href="/user-games/recent">View Recently Played Games</a>
+				<br/>
+				<a href="/user-games/untouched?months=6">View Games Not Played in 6 Months</a>
+				<br/>
+				<a href="/user-games/prices">View Library Prices</a>
+				<br/>
+				<a href="/wishlist">View Wishlist</a>
+				<br/>
+				<a href="/games/achievements">View Achievement Summary</a>
+				<br/>
+				<a href="/games/search?q=portal">Search Games</a>
+				<br/>
+				<a href="/friends">View Friends</a>
+				<br/>
+				<a href="/news">View News Feed</a>
+				<br/>
+				<a href="/market/753/311690-Frifle and Mauser">Market Price for Frifle and Mauser</a>
 				<br/>
-				<a href="/market/311690-Frifle and Mauser">New Test for Price for Frifle and Mauser</a>
+				<a href="/market/753/311690-Frifle and Mauser/history">Market Price History for Frifle and Mauser</a>
 				<br/>
             </body>
         </html>
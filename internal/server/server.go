@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 	"github.com/masintxi/gamehub/internal/auth"
 	"github.com/masintxi/gamehub/internal/client"
 	"github.com/masintxi/gamehub/internal/handlers"
@@ -18,16 +18,19 @@ type Server struct {
 	Handlers  *handlers.SteamHandlers
 	Port      string
 	Domain    string
+	Debug     bool // Serves the /debug routes, they aren't tied to any user
+	Config    handlers.HandlersConfig
 }
 
 func NewServer(client *client.Client, steamAuth *auth.SteamAuth, server *Server) *Server {
 	r := chi.NewRouter()
 
-	// Middleware
+	// Middleware, the request ID goes in error responses and the logs
+	r.Use(middleware.RequestID)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 
-	handlers := handlers.NewSteamHandlers(client, steamAuth)
+	handlers := handlers.NewSteamHandlers(client, steamAuth, server.Config)
 
 	server.Router = r
 	server.Client = client
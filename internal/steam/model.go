@@ -0,0 +1,324 @@
+package steam
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Community visibility state of a profile anyone can see.
+const publicVisibility = 3
+
+// Release date layouts of the english store. Other dates, such as "Q3 2025"
+// or "Coming soon", only keep their text.
+var releaseDateLayouts = []string{"2 Jan, 2006", "Jan 2, 2006", "2 Jan 2006", "Jan 2006"}
+
+// Game is the store page of an app.
+type Game struct {
+	AppID            int       `json:"appid"`
+	Name             string    `json:"name"`
+	Type             string    `json:"type"`
+	Free             bool      `json:"free"`
+	ShortDescription string    `json:"short_description"`
+	Categories       []string  `json:"categories"`
+	Genres           []string  `json:"genres"`
+	DLC              []int     `json:"dlc"`
+	ComingSoon       bool      `json:"coming_soon"`
+	ReleaseDate      time.Time `json:"release_date"` // Zero when the store gives no day
+	ReleaseDateText  string    `json:"release_date_text"`
+	Price            *Price    `json:"price,omitempty"` // Nil for free or unpriced apps
+}
+
+type Price struct {
+	Currency        string `json:"currency"`
+	Initial         int    `json:"initial"` // In minor units
+	Final           int    `json:"final"`
+	DiscountPercent int    `json:"discount_percent"`
+}
+
+// OwnedGame is a game of a library. Playtimes are in minutes.
+type OwnedGame struct {
+	AppID          int       `json:"appid"`
+	Name           string    `json:"name"`
+	IconHash       string    `json:"icon_hash"`
+	Playtime       int       `json:"playtime"`
+	Playtime2Weeks int       `json:"playtime_2weeks"`
+	PlaytimeDeck   int       `json:"playtime_deck"`
+	LastPlayed     time.Time `json:"last_played"` // Zero when never played
+	HasStats       bool      `json:"has_stats"`
+	HasWorkshop    bool      `json:"has_workshop"`
+	HasMarket      bool      `json:"has_market"`
+	HasDLC         bool      `json:"has_dlc"`
+}
+
+// InventoryItem is an asset joined with its description.
+type InventoryItem struct {
+	AppID          int                `json:"appid"`
+	ContextID      uint64             `json:"contextid"`
+	AssetID        uint64             `json:"assetid"`
+	ClassID        uint64             `json:"classid"`
+	InstanceID     uint64             `json:"instanceid"`
+	Amount         int                `json:"amount"`
+	Name           string             `json:"name"`
+	MarketName     string             `json:"market_name"`
+	MarketHashName string             `json:"market_hash_name"`
+	Type           string             `json:"type"`
+	IconURL        string             `json:"icon_url"`
+	Tradable       bool               `json:"tradable"`
+	Marketable     bool               `json:"marketable"`
+	Commodity      bool               `json:"commodity"`
+	TradeHoldDays  int                `json:"trade_hold_days"`         // Days before a bought copy can be traded
+	Tags           map[string]string  `json:"tags,omitempty"`          // Localized tag name by category
+	InternalTags   map[string]string  `json:"internal_tags,omitempty"` // Internal tag name by category, the same in every language
+	Descriptions   []DescriptionValue `json:"descriptions,omitempty"`
+}
+
+// Player is the public summary of a profile.
+type Player struct {
+	SteamID      string    `json:"steamid"`
+	Name         string    `json:"name"`
+	RealName     string    `json:"real_name,omitempty"`
+	ProfileURL   string    `json:"profile_url"`
+	Avatar       string    `json:"avatar"`
+	AvatarFull   string    `json:"avatar_full"`
+	Public       bool      `json:"public"`
+	State        int       `json:"state"` // 0 offline, 1 online, 2 busy, 3 away...
+	CountryCode  string    `json:"country_code,omitempty"`
+	PlayingAppID int       `json:"playing_appid,omitempty"`
+	Playing      string    `json:"playing,omitempty"`
+	LastLogoff   time.Time `json:"last_logoff"` // Zero when hidden
+	Created      time.Time `json:"created"`     // Zero when hidden
+}
+
+// NewGame converts an appdetails entry. A failed entry gives a zero Game.
+func NewGame(raw GameData) Game {
+	if !raw.Success {
+		return Game{}
+	}
+
+	data := raw.Data
+	game := Game{
+		AppID:            data.SteamAppid,
+		Name:             data.Name,
+		Type:             data.Type,
+		Free:             data.IsFree,
+		ShortDescription: data.ShortDescription,
+		Categories:       make([]string, 0, len(data.Categories)),
+		Genres:           make([]string, 0, len(data.Genres)),
+		DLC:              data.Dlc,
+		ComingSoon:       data.ReleaseDate.ComingSoon,
+		ReleaseDateText:  data.ReleaseDate.Date,
+	}
+	for _, category := range data.Categories {
+		game.Categories = append(game.Categories, category.Description)
+	}
+	for _, genre := range data.Genres {
+		game.Genres = append(game.Genres, genre.Description)
+	}
+	for _, layout := range releaseDateLayouts {
+		if date, err := time.Parse(layout, data.ReleaseDate.Date); err == nil {
+			game.ReleaseDate = date
+			break
+		}
+	}
+	if price := data.PriceOverview; price.Currency != "" {
+		game.Price = &Price{
+			Currency:        price.Currency,
+			Initial:         price.Initial,
+			Final:           price.Final,
+			DiscountPercent: price.DiscountPercent,
+		}
+	}
+	return game
+}
+
+// NewOwnedGame converts a game of GetOwnedGames or GetRecentlyPlayedGames.
+func NewOwnedGame(raw GameFromList) OwnedGame {
+	return OwnedGame{
+		AppID:          raw.AppID,
+		Name:           raw.Name,
+		IconHash:       raw.ImgIconURL,
+		Playtime:       raw.PlaytimeForever,
+		Playtime2Weeks: raw.Playtime2Weeks,
+		PlaytimeDeck:   raw.PlaytimeDeckForever,
+		LastPlayed:     unixTime(int64(raw.RtimeLastPlayed)),
+		HasStats:       raw.HasCommunityVisibleStats,
+		HasWorkshop:    raw.HasWorkshop,
+		HasMarket:      raw.HasMarket,
+		HasDLC:         raw.HasDlc,
+	}
+}
+
+// NewOwnedGames converts a GetOwnedGames response.
+func NewOwnedGames(raw OwnedGamesResponse) []OwnedGame {
+	games := make([]OwnedGame, len(raw.Response.Games))
+	for i, game := range raw.Response.Games {
+		games[i] = NewOwnedGame(game)
+	}
+	return games
+}
+
+// NewPlayer converts a player summary. Private profiles only show the
+// name, the avatar and the state.
+func NewPlayer(raw PlayerSummary) Player {
+	player := Player{
+		SteamID:     raw.Steamid,
+		Name:        raw.Personaname,
+		RealName:    raw.Realname,
+		ProfileURL:  raw.Profileurl,
+		Avatar:      raw.Avatarmedium,
+		AvatarFull:  raw.Avatarfull,
+		Public:      raw.Communityvisibilitystate == publicVisibility,
+		State:       raw.Personastate,
+		CountryCode: raw.Loccountrycode,
+		Playing:     raw.Gameextrainfo,
+		LastLogoff:  unixTime(int64(raw.Lastlogoff)),
+		Created:     unixTime(int64(raw.Timecreated)),
+	}
+	player.PlayingAppID, _ = strconv.Atoi(raw.Gameid)
+	return player
+}
+
+// NewInventoryItems joins every asset of an inventory page with its
+// description. Steam only sends the descriptions needed by the assets of the
+// same page; assets without one keep their IDs and amount only.
+func NewInventoryItems(page InventoryResponse) ([]InventoryItem, error) {
+	descriptions := make(map[string]Description, len(page.Descriptions))
+	for _, desc := range page.Descriptions {
+		descriptions[desc.Classid+"_"+desc.Instanceid] = desc
+	}
+
+	items := make([]InventoryItem, 0, len(page.Assets))
+	for _, asset := range page.Assets {
+		item, err := newInventoryItem(asset)
+		if err != nil {
+			return nil, err
+		}
+		if desc, ok := descriptions[asset.ClassID+"_"+asset.InstanceID]; ok {
+			item.Name = desc.Name
+			item.MarketName = desc.MarketName
+			item.MarketHashName = desc.MarketHashName
+			item.Type = desc.Type
+			item.IconURL = desc.IconURL
+			item.Tradable = desc.Tradable == 1
+			item.Marketable = desc.Marketable == 1
+			item.Commodity = desc.Commodity == 1
+			item.TradeHoldDays = desc.MarketTradableRestriction
+			item.Descriptions = desc.Descriptions
+			if len(desc.Tags) > 0 {
+				item.Tags = make(map[string]string, len(desc.Tags))
+				item.InternalTags = make(map[string]string, len(desc.Tags))
+				for _, tag := range desc.Tags {
+					item.Tags[tag.Category] = tag.LocalizedTagName
+					item.InternalTags[tag.Category] = tag.InternalName
+				}
+			}
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// NewTradeInventoryItems converts a GetInventoryItemsWithDescriptions
+// response, where every item carries its own description.
+func NewTradeInventoryItems(raw TradeInventoryResponse) ([]InventoryItem, error) {
+	items := make([]InventoryItem, 0, len(raw.Response.Items))
+	for _, rawItem := range raw.Response.Items {
+		item, err := newInventoryItem(Asset{
+			AppID:      rawItem.AppID,
+			ContextID:  rawItem.ContextID,
+			AssetID:    rawItem.AssetID,
+			ClassID:    rawItem.ClassID,
+			InstanceID: rawItem.InstanceID,
+			Amount:     rawItem.Amount,
+		})
+		if err != nil {
+			return nil, err
+		}
+		item.Name = rawItem.Desc.Name
+		item.MarketName = rawItem.Desc.MarketName
+		item.MarketHashName = rawItem.Desc.MarketHash
+		item.Type = rawItem.Desc.Type
+		item.IconURL = rawItem.Desc.IconURL
+		item.Tradable = rawItem.Desc.Tradable == 1
+		item.Marketable = rawItem.Desc.Marketable == 1
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func newInventoryItem(asset Asset) (InventoryItem, error) {
+	item := InventoryItem{AppID: asset.AppID}
+
+	ids := []struct {
+		name  string
+		value string
+		dst   *uint64
+	}{
+		{"contextid", asset.ContextID, &item.ContextID},
+		{"assetid", asset.AssetID, &item.AssetID},
+		{"classid", asset.ClassID, &item.ClassID},
+		{"instanceid", asset.InstanceID, &item.InstanceID},
+	}
+	for _, id := range ids {
+		value, err := strconv.ParseUint(id.value, 10, 64)
+		if err != nil {
+			return InventoryItem{}, fmt.Errorf("asset %s: invalid %s %q", asset.AssetID, id.name, id.value)
+		}
+		*id.dst = value
+	}
+
+	amount, err := strconv.Atoi(asset.Amount)
+	if err != nil {
+		return InventoryItem{}, fmt.Errorf("asset %s: invalid amount %q", asset.AssetID, asset.Amount)
+	}
+	item.Amount = amount
+
+	return item, nil
+}
+
+// ParsePrice reads a price as Steam shows it, such as "1,95€", "$1,234.56"
+// or "¥ 1,980", in minor units. Steam counts every currency in hundredths,
+// even the ones it shows without decimals.
+func ParsePrice(text string) (int, error) {
+	// Keep the digits and separators, symbols and codes can be on either side
+	digits := strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == ',' || r == '.' {
+			return r
+		}
+		return -1
+	}, text)
+	digits = strings.Trim(digits, ",.")
+	if digits == "" {
+		return 0, fmt.Errorf("invalid price %q", text)
+	}
+
+	// The last separator marks the decimals when one or two digits follow
+	// it, otherwise it groups thousands
+	whole, fraction := digits, ""
+	if i := strings.LastIndexAny(digits, ",."); i >= 0 && len(digits)-i-1 <= 2 {
+		whole, fraction = digits[:i], digits[i+1:]
+	}
+	whole = strings.NewReplacer(",", "", ".", "").Replace(whole)
+
+	units, err := strconv.Atoi(whole)
+	if err != nil {
+		return 0, fmt.Errorf("invalid price %q", text)
+	}
+	cents := 0
+	if fraction != "" {
+		cents, _ = strconv.Atoi(fraction + strings.Repeat("0", 2-len(fraction)))
+	}
+	return units*100 + cents, nil
+}
+
+// unixTime converts Steam timestamps, where 0 means unknown.
+func unixTime(seconds int64) time.Time {
+	if seconds <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0).UTC()
+}
@@ -0,0 +1,196 @@
+package steam
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func loadFixture(t *testing.T, name string, v interface{}) {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("reading %s: %v", name, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("decoding %s: %v", name, err)
+	}
+}
+
+func TestNewGame(t *testing.T) {
+	var apps map[string]GameData
+	loadFixture(t, "appdetails.json", &apps)
+
+	portal := NewGame(apps["620"])
+	if portal.AppID != 620 || portal.Name != "Portal 2" || portal.Free {
+		t.Errorf("unexpected game %+v", portal)
+	}
+	if want := time.Date(2011, 4, 18, 0, 0, 0, 0, time.UTC); !portal.ReleaseDate.Equal(want) {
+		t.Errorf("expected release date %v, got %v", want, portal.ReleaseDate)
+	}
+	if len(portal.Categories) != 2 || portal.Categories[1] != "Co-op" || portal.Genres[0] != "Action" {
+		t.Errorf("unexpected categories %v and genres %v", portal.Categories, portal.Genres)
+	}
+	if portal.Price == nil || portal.Price.Final != 195 || portal.Price.DiscountPercent != 80 {
+		t.Errorf("unexpected price %+v", portal.Price)
+	}
+
+	silksong := NewGame(apps["1030300"])
+	if !silksong.ComingSoon || !silksong.ReleaseDate.IsZero() || silksong.ReleaseDateText != "To be announced" {
+		t.Errorf("expected an undated coming soon game, got %+v", silksong)
+	}
+	if silksong.Price != nil {
+		t.Errorf("expected no price, got %+v", silksong.Price)
+	}
+
+	if failed := NewGame(apps["1"]); failed.AppID != 0 {
+		t.Errorf("expected a zero game for a failed entry, got %+v", failed)
+	}
+}
+
+func TestNewOwnedGames(t *testing.T) {
+	var raw OwnedGamesResponse
+	loadFixture(t, "owned_games.json", &raw)
+
+	games := NewOwnedGames(raw)
+	if len(games) != 3 {
+		t.Fatalf("expected 3 games, got %d", len(games))
+	}
+
+	portal := games[1]
+	if portal.Playtime != 2890 || portal.Playtime2Weeks != 95 || portal.PlaytimeDeck != 120 {
+		t.Errorf("unexpected playtimes %+v", portal)
+	}
+	if !portal.LastPlayed.Equal(time.Unix(1700000000, 0)) || !portal.HasStats || !portal.HasWorkshop {
+		t.Errorf("unexpected game %+v", portal)
+	}
+	if !games[2].LastPlayed.IsZero() || games[2].HasStats {
+		t.Errorf("expected a never played game, got %+v", games[2])
+	}
+}
+
+func TestNewPlayer(t *testing.T) {
+	var raw PlayerResponse
+	loadFixture(t, "player_summaries.json", &raw)
+
+	player := NewPlayer(raw.Response.Players[0])
+	if !player.Public || player.Name != "Rabscuttle" || player.CountryCode != "US" {
+		t.Errorf("unexpected player %+v", player)
+	}
+	if player.PlayingAppID != 620 || player.Playing != "Portal 2" {
+		t.Errorf("expected player to be in Portal 2, got %+v", player)
+	}
+	if !player.Created.Equal(time.Unix(1063407589, 0)) || player.Created.Location() != time.UTC {
+		t.Errorf("unexpected creation time %v", player.Created)
+	}
+
+	private := NewPlayer(raw.Response.Players[1])
+	if private.Public || !private.Created.IsZero() || !private.LastLogoff.IsZero() || private.PlayingAppID != 0 {
+		t.Errorf("expected a private player without times, got %+v", private)
+	}
+}
+
+func TestNewInventoryItems(t *testing.T) {
+	var page InventoryResponse
+	loadFixture(t, "inventory.json", &page)
+
+	items, err := NewInventoryItems(page)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("expected 4 items, got %d", len(items))
+	}
+
+	redline := items[0]
+	if redline.AssetID != 38350174520 || redline.ContextID != 2 || redline.ClassID != 7530010 {
+		t.Errorf("unexpected IDs %+v", redline)
+	}
+	if !redline.Tradable || !redline.Marketable || redline.Commodity || redline.TradeHoldDays != 7 {
+		t.Errorf("unexpected flags %+v", redline)
+	}
+	if redline.Tags["Exterior"] != "Field-Tested" || redline.InternalTags["Exterior"] != "WearCategory2" {
+		t.Errorf("expected tags by category, got %v and %v", redline.Tags, redline.InternalTags)
+	}
+	if len(redline.Descriptions) != 1 || redline.Descriptions[0].Value != "Exterior: Field-Tested" {
+		t.Errorf("expected the description lines, got %+v", redline.Descriptions)
+	}
+
+	if asiimov := items[1]; asiimov.InstanceID != 188530139 || asiimov.Tradable || !asiimov.Marketable {
+		t.Errorf("expected a marketable item that can't be traded, got %+v", asiimov)
+	}
+	if cases := items[2]; cases.Amount != 14 || !cases.Commodity {
+		t.Errorf("expected a stack of 14 commodities, got %+v", cases)
+	}
+	if unknown := items[3]; unknown.Name != "" || unknown.AssetID != 38350174523 || unknown.Amount != 1 {
+		t.Errorf("expected an item without description to keep its IDs, got %+v", unknown)
+	}
+
+	page.Assets[0].Amount = "lots"
+	if _, err := NewInventoryItems(page); err == nil {
+		t.Error("expected an error for an invalid amount")
+	}
+}
+
+func TestNewTradeInventoryItems(t *testing.T) {
+	var raw TradeInventoryResponse
+	loadFixture(t, "trade_inventory.json", &raw)
+
+	items, err := NewTradeInventoryItems(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+
+	redline := items[0]
+	if redline.AssetID != 38350174520 || redline.MarketHashName != "AK-47 | Redline (Field-Tested)" {
+		t.Errorf("unexpected item %+v", redline)
+	}
+	if !redline.Tradable || !redline.Marketable {
+		t.Errorf("unexpected flags %+v", redline)
+	}
+	if cases := items[1]; cases.Amount != 14 || cases.Tradable || !cases.Marketable {
+		t.Errorf("expected a stack of 14 untradable cases, got %+v", cases)
+	}
+
+	raw.Response.Items[0].AssetID = "none"
+	if _, err := NewTradeInventoryItems(raw); err == nil {
+		t.Error("expected an error for an invalid asset ID")
+	}
+}
+
+func TestParsePrice(t *testing.T) {
+	cases := []struct {
+		text string
+		want int
+	}{
+		{"1,95€", 195},
+		{"$31.50", 3150},
+		{"$1,234.56", 123456},
+		{"1.234,56€", 123456},
+		{"£0.03", 3},
+		{"¥ 1,980", 198000},
+		{"R$ 1,5", 150},
+		{"CDN$ 12.99", 1299},
+		{"12,34 pуб.", 1234},
+		{"Rp 12 345", 1234500},
+		{"₹ 85", 8500},
+	}
+	for _, c := range cases {
+		got, err := ParsePrice(c.text)
+		if err != nil || got != c.want {
+			t.Errorf("ParsePrice(%q) = %d, %v, want %d", c.text, got, err, c.want)
+		}
+	}
+
+	for _, text := range []string{"", "--", "Free"} {
+		if _, err := ParsePrice(text); err == nil {
+			t.Errorf("expected an error for %q", text)
+		}
+	}
+}
@@ -0,0 +1,443 @@
+package steam
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Raw Steam payloads, decoded as they come. Steam sends asset IDs and
+// amounts as strings, flags as 0/1 ints and times as Unix seconds; the
+// domain types in model.go clean that up.
+
+type OwnedGamesResponse struct {
+	Response struct {
+		GameCount int            `json:"game_count"`
+		Games     []GameFromList `json:"games"`
+	} `json:"response"`
+}
+
+type RecentlyPlayedGames struct {
+	Response struct {
+		TotalCount int            `json:"total_count"`
+		Games      []GameFromList `json:"games"`
+	} `json:"response"`
+}
+
+type GameFromList struct {
+	AppID                    int    `json:"appid"`
+	Name                     string `json:"name"`
+	PlaytimeForever          int    `json:"playtime_forever"`
+	ImgIconURL               string `json:"img_icon_url"`
+	HasCommunityVisibleStats bool   `json:"has_community_visible_stats,omitempty"`
+	PlaytimeWindowsForever   int    `json:"playtime_windows_forever"`
+	PlaytimeMacForever       int    `json:"playtime_mac_forever"`
+	PlaytimeLinuxForever     int    `json:"playtime_linux_forever"`
+	PlaytimeDeckForever      int    `json:"playtime_deck_forever"`
+	RtimeLastPlayed          int    `json:"rtime_last_played"`
+	CapsuleFilename          string `json:"capsule_filename"`
+	HasWorkshop              bool   `json:"has_workshop"`
+	HasMarket                bool   `json:"has_market"`
+	HasDlc                   bool   `json:"has_dlc"`
+	ContentDescriptorids     []int  `json:"content_descriptorids,omitempty"`
+	PlaytimeDisconnected     int    `json:"playtime_disconnected"`
+	HasLeaderboards          bool   `json:"has_leaderboards,omitempty"`
+	SortAs                   string `json:"sort_as,omitempty"`
+	Playtime2Weeks           int    `json:"playtime_2weeks,omitempty"`
+}
+
+type GameData struct {
+	Success bool `json:"success"`
+	Data    struct {
+		Type                string        `json:"type"`
+		Name                string        `json:"name"`
+		SteamAppid          int           `json:"steam_appid"`
+		IsFree              bool          `json:"is_free"`
+		ControllerSupport   string        `json:"controller_support"`
+		Dlc                 []int         `json:"dlc"`
+		DetailedDescription string        `json:"detailed_description"`
+		AboutTheGame        string        `json:"about_the_game"`
+		ShortDescription    string        `json:"short_description"`
+		SupportedLanguages  string        `json:"supported_languages"`
+		PriceOverview       PriceOverview `json:"price_overview"`
+		Categories          []Category    `json:"categories"`
+		Genres              []struct {
+			ID          string `json:"id"`
+			Description string `json:"description"`
+		} `json:"genres"`
+		ReleaseDate struct {
+			ComingSoon bool   `json:"coming_soon"`
+			Date       string `json:"date"`
+		} `json:"release_date"`
+	} `json:"data"`
+}
+
+// Category is a store feature of a game, such as Single-player or Co-op.
+type Category struct {
+	ID          int    `json:"id"`
+	Description string `json:"description"`
+}
+
+type PriceOverview struct {
+	Currency         string `json:"currency"`
+	Initial          int    `json:"initial"`
+	Final            int    `json:"final"`
+	DiscountPercent  int    `json:"discount_percent"`
+	InitialFormatted string `json:"initial_formatted"`
+	FinalFormatted   string `json:"final_formatted"`
+}
+
+type InventoryResponse struct {
+	Assets              []Asset       `json:"assets"`
+	Descriptions        []Description `json:"descriptions"`
+	MoreItems           int           `json:"more_items"`
+	LastAssetid         string        `json:"last_assetid"`
+	TotalInventoryCount int           `json:"total_inventory_count"`
+	Success             int           `json:"success"`
+	Rwgrsn              int           `json:"rwgrsn"`
+}
+
+type Asset struct {
+	AppID      int    `json:"appid"`
+	ContextID  string `json:"contextid"`
+	AssetID    string `json:"assetid"`
+	ClassID    string `json:"classid"`
+	InstanceID string `json:"instanceid"`
+	Amount     string `json:"amount"`
+}
+
+type Description struct {
+	Appid                       int                `json:"appid"`
+	Classid                     string             `json:"classid"`
+	Instanceid                  string             `json:"instanceid"`
+	Currency                    int                `json:"currency"`
+	BackgroundColor             string             `json:"background_color"`
+	IconURL                     string             `json:"icon_url"`
+	IconURLLarge                string             `json:"icon_url_large"`
+	Descriptions                []DescriptionValue `json:"descriptions"`
+	Tradable                    int                `json:"tradable"`
+	Name                        string             `json:"name"`
+	Type                        string             `json:"type"`
+	MarketName                  string             `json:"market_name"`
+	MarketHashName              string             `json:"market_hash_name"`
+	MarketFeeApp                int                `json:"market_fee_app"`
+	Commodity                   int                `json:"commodity"`
+	MarketTradableRestriction   int                `json:"market_tradable_restriction"`
+	MarketMarketableRestriction int                `json:"market_marketable_restriction"`
+	Marketable                  int                `json:"marketable"`
+	Tags                        []Tag              `json:"tags"`
+}
+
+type DescriptionValue struct {
+	Value string `json:"value"`
+}
+
+type Tag struct {
+	Category              string `json:"category"`
+	InternalName          string `json:"internal_name"`
+	LocalizedCategoryName string `json:"localized_category_name"`
+	LocalizedTagName      string `json:"localized_tag_name"`
+}
+
+type PlayerResponse struct {
+	Response struct {
+		Players []PlayerSummary `json:"players"`
+	} `json:"response"`
+}
+
+type PlayerSummary struct {
+	Steamid                  string `json:"steamid"`
+	Communityvisibilitystate int    `json:"communityvisibilitystate"`
+	Profilestate             int    `json:"profilestate"`
+	Personaname              string `json:"personaname"`
+	Commentpermission        int    `json:"commentpermission"`
+	Profileurl               string `json:"profileurl"`
+	Avatar                   string `json:"avatar"`
+	Avatarmedium             string `json:"avatarmedium"`
+	Avatarfull               string `json:"avatarfull"`
+	Avatarhash               string `json:"avatarhash"`
+	Lastlogoff               int    `json:"lastlogoff"`
+	Personastate             int    `json:"personastate"`
+	Realname                 string `json:"realname"`
+	Primaryclanid            string `json:"primaryclanid"`
+	Timecreated              int    `json:"timecreated"`
+	Personastateflags        int    `json:"personastateflags"`
+	Loccountrycode           string `json:"loccountrycode,omitempty"`
+	Gameid                   string `json:"gameid,omitempty"`
+	Gameextrainfo            string `json:"gameextrainfo,omitempty"`
+}
+
+type MarketPriceOverview struct {
+	Success     bool   `json:"success"`
+	LowestPrice string `json:"lowest_price"` // Formatted, such as "1,95€"
+	Volume      string `json:"volume"`       // Sales in the last 24 hours, such as "1,204"
+	MedianPrice string `json:"median_price"`
+}
+
+type OrderHistogramResponse struct {
+	Success         int               `json:"success"`
+	HighestBuyOrder string            `json:"highest_buy_order"` // In minor units, empty without bids
+	LowestSellOrder string            `json:"lowest_sell_order"`
+	BuyOrderGraph   []OrderGraphPoint `json:"buy_order_graph"`
+	SellOrderGraph  []OrderGraphPoint `json:"sell_order_graph"`
+	PricePrefix     string            `json:"price_prefix"`
+	PriceSuffix     string            `json:"price_suffix"`
+}
+
+// OrderGraphPoint is a [price, quantity, label] entry of the order graphs.
+// The quantity counts every order at this price or better.
+type OrderGraphPoint struct {
+	Price    float64
+	Quantity int
+	Label    string
+}
+
+func (p *OrderGraphPoint) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) < 2 {
+		return fmt.Errorf("order graph point: expected 3 values, got %d", len(raw))
+	}
+	if err := json.Unmarshal(raw[0], &p.Price); err != nil {
+		return fmt.Errorf("order graph point price: %w", err)
+	}
+	if err := json.Unmarshal(raw[1], &p.Quantity); err != nil {
+		return fmt.Errorf("order graph point quantity: %w", err)
+	}
+	if len(raw) > 2 {
+		json.Unmarshal(raw[2], &p.Label)
+	}
+	return nil
+}
+
+type ListingsResponse struct {
+	Success     bool        `json:"success"`
+	Start       int         `json:"start"`
+	PageSize    int         `json:"pagesize"`
+	TotalCount  int         `json:"total_count"`
+	ListingInfo ListingInfo `json:"listinginfo"`
+}
+
+// ListingInfo holds the listings of a page by listing ID. Pages without
+// listings send an empty array instead of an object.
+type ListingInfo map[string]Listing
+
+func (l *ListingInfo) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		*l = ListingInfo{}
+		return nil
+	}
+	return json.Unmarshal(data, (*map[string]Listing)(l))
+}
+
+// Listing amounts are in minor units. The price is what the seller gets;
+// buyers pay the price plus the fee. Converted amounts are in the currency
+// of the request.
+type Listing struct {
+	ListingID             string `json:"listingid"`
+	Price                 int    `json:"price"`
+	Fee                   int    `json:"fee"`
+	SteamFee              int    `json:"steam_fee"`
+	PublisherFee          int    `json:"publisher_fee"`
+	CurrencyID            int    `json:"currencyid"` // 2000 plus the wallet currency id
+	ConvertedPrice        int    `json:"converted_price"`
+	ConvertedFee          int    `json:"converted_fee"`
+	ConvertedSteamFee     int    `json:"converted_steam_fee"`
+	ConvertedPublisherFee int    `json:"converted_publisher_fee"`
+	ConvertedCurrencyID   int    `json:"converted_currencyid"`
+	Asset                 struct {
+		AppID     int    `json:"appid"`
+		ContextID string `json:"contextid"`
+		ID        string `json:"id"`
+		Amount    string `json:"amount"`
+	} `json:"asset"`
+}
+
+type TradeInventoryResponse struct {
+	Response struct {
+		Items []struct {
+			AppID      int    `json:"appid"`
+			ContextID  string `json:"contextid"`
+			AssetID    string `json:"assetid"`
+			ClassID    string `json:"classid"`
+			InstanceID string `json:"instanceid"`
+			Amount     string `json:"amount"`
+			Desc       struct {
+				Type       string `json:"type"`
+				Name       string `json:"name"`
+				MarketName string `json:"market_name"`
+				MarketHash string `json:"market_hash_name"`
+				IconURL    string `json:"icon_url"`
+				Tradable   int    `json:"tradable"`
+				Marketable int    `json:"marketable"`
+			} `json:"descriptions"`
+		} `json:"items"`
+		TotalInventoryCount int `json:"total_inventory_count"`
+	} `json:"response"`
+}
+
+type WishlistResponse struct {
+	Response struct {
+		Items []struct {
+			AppID     int   `json:"appid"`
+			Priority  int   `json:"priority"`
+			DateAdded int64 `json:"date_added"`
+		} `json:"items"`
+	} `json:"response"`
+}
+
+type PlayerAchievementsResponse struct {
+	Playerstats struct {
+		SteamID      string `json:"steamID"`
+		GameName     string `json:"gameName"`
+		Achievements []struct {
+			Apiname    string `json:"apiname"`
+			Achieved   int    `json:"achieved"`
+			Unlocktime int64  `json:"unlocktime"`
+		} `json:"achievements"`
+		Success bool   `json:"success"`
+		Error   string `json:"error"`
+	} `json:"playerstats"`
+}
+
+type GameSchemaResponse struct {
+	Game struct {
+		GameName           string `json:"gameName"`
+		GameVersion        string `json:"gameVersion"`
+		AvailableGameStats struct {
+			Achievements []struct {
+				Name         string `json:"name"`
+				DefaultValue int    `json:"defaultvalue"`
+				DisplayName  string `json:"displayName"`
+				Hidden       int    `json:"hidden"`
+				Description  string `json:"description"`
+				Icon         string `json:"icon"`
+				Icongray     string `json:"icongray"`
+			} `json:"achievements"`
+		} `json:"availableGameStats"`
+	} `json:"game"`
+}
+
+type GlobalAchievementPercentagesResponse struct {
+	Achievementpercentages struct {
+		Achievements []struct {
+			Name    string      `json:"name"`
+			Percent json.Number `json:"percent"`
+		} `json:"achievements"`
+	} `json:"achievementpercentages"`
+}
+
+type SteamLevelResponse struct {
+	Response struct {
+		PlayerLevel int `json:"player_level"`
+	} `json:"response"`
+}
+
+type BadgesResponse struct {
+	Response struct {
+		Badges []struct {
+			BadgeID        int    `json:"badgeid"`
+			AppID          int    `json:"appid"`
+			Level          int    `json:"level"`
+			CompletionTime int64  `json:"completion_time"`
+			XP             int    `json:"xp"`
+			CommunityItem  string `json:"communityitemid"`
+			BorderColor    int    `json:"border_color"`
+			Scarcity       int    `json:"scarcity"`
+		} `json:"badges"`
+		PlayerXP                   int `json:"player_xp"`
+		PlayerLevel                int `json:"player_level"`
+		PlayerXPNeededToLevelUp    int `json:"player_xp_needed_to_level_up"`
+		PlayerXPNeededCurrentLevel int `json:"player_xp_needed_current_level"`
+	} `json:"response"`
+}
+
+type CommunityBadgeProgressResponse struct {
+	Response struct {
+		Quests []struct {
+			QuestID   int  `json:"questid"`
+			Completed bool `json:"completed"`
+		} `json:"quests"`
+	} `json:"response"`
+}
+
+type MarketSearchResponse struct {
+	Success    bool                 `json:"success"`
+	Start      int                  `json:"start"`
+	PageSize   int                  `json:"pagesize"`
+	TotalCount int                  `json:"total_count"`
+	Results    []MarketSearchResult `json:"results"`
+}
+
+type MarketSearchResult struct {
+	Name          string `json:"name"`
+	HashName      string `json:"hash_name"`
+	SellListings  int    `json:"sell_listings"`
+	SellPrice     int    `json:"sell_price"`
+	SellPriceText string `json:"sell_price_text"`
+}
+
+type PlayerBansResponse struct {
+	Players []PlayerBan `json:"players"`
+}
+
+type PlayerBan struct {
+	SteamID          string `json:"SteamId"`
+	CommunityBanned  bool   `json:"CommunityBanned"`
+	VACBanned        bool   `json:"VACBanned"`
+	NumberOfVACBans  int    `json:"NumberOfVACBans"`
+	DaysSinceLastBan int    `json:"DaysSinceLastBan"`
+	NumberOfGameBans int    `json:"NumberOfGameBans"`
+	EconomyBan       string `json:"EconomyBan"`
+}
+
+type AppNewsResponse struct {
+	Appnews struct {
+		AppID     int           `json:"appid"`
+		Newsitems []AppNewsItem `json:"newsitems"`
+		Count     int           `json:"count"`
+	} `json:"appnews"`
+}
+
+type AppNewsItem struct {
+	Gid           string   `json:"gid"`
+	Title         string   `json:"title"`
+	URL           string   `json:"url"`
+	IsExternalURL bool     `json:"is_external_url"`
+	Author        string   `json:"author"`
+	Contents      string   `json:"contents"`
+	Feedlabel     string   `json:"feedlabel"`
+	Date          int64    `json:"date"`
+	Feedname      string   `json:"feedname"`
+	FeedType      int      `json:"feed_type"`
+	AppID         int      `json:"appid"`
+	Tags          []string `json:"tags"`
+}
+
+type PlayerCountResponse struct {
+	Response struct {
+		PlayerCount int `json:"player_count"`
+		Result      int `json:"result"`
+	} `json:"response"`
+}
+
+type AppListResponse struct {
+	Applist struct {
+		Apps []struct {
+			AppID int    `json:"appid"`
+			Name  string `json:"name"`
+		} `json:"apps"`
+	} `json:"applist"`
+}
+
+type FriendListResponse struct {
+	Friendslist struct {
+		Friends []struct {
+			Steamid      string `json:"steamid"`
+			Relationship string `json:"relationship"`
+			FriendSince  int64  `json:"friend_since"`
+		} `json:"friends"`
+	} `json:"friendslist"`
+}
@@ -0,0 +1,48 @@
+package steam
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestListingsResponse(t *testing.T) {
+	raw := `{
+		"success": true, "start": 0, "pagesize": 10, "total_count": 2,
+		"listinginfo": {
+			"4521": {"listingid": "4521", "price": 2739, "fee": 411, "steam_fee": 136, "publisher_fee": 275, "currencyid": 2001,
+				"converted_price": 2519, "converted_fee": 377, "converted_currencyid": 2003,
+				"asset": {"appid": 730, "contextid": "2", "id": "38350174520", "amount": "1"}}
+		}
+	}`
+
+	var listings ListingsResponse
+	if err := json.Unmarshal([]byte(raw), &listings); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	listing, ok := listings.ListingInfo["4521"]
+	if !ok || listing.ConvertedPrice != 2519 || listing.Asset.ID != "38350174520" {
+		t.Errorf("unexpected listings %+v", listings.ListingInfo)
+	}
+
+	// Pages without listings send an empty array
+	if err := json.Unmarshal([]byte(`{"success": true, "total_count": 0, "listinginfo": []}`), &listings); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(listings.ListingInfo) != 0 {
+		t.Errorf("expected no listings, got %+v", listings.ListingInfo)
+	}
+}
+
+func TestOrderGraphPoint(t *testing.T) {
+	var graph []OrderGraphPoint
+	if err := json.Unmarshal([]byte(`[[0.52, 1204, "1204 sell orders at $0.52 or lower"], [0.53, 1300]]`), &graph); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(graph) != 2 || graph[0].Price != 0.52 || graph[0].Quantity != 1204 || graph[1].Label != "" {
+		t.Errorf("unexpected graph %+v", graph)
+	}
+
+	if err := json.Unmarshal([]byte(`[[0.52]]`), &graph); err == nil {
+		t.Error("expected an error for a point without quantity")
+	}
+}
@@ -0,0 +1,209 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Store keeps data that must outlive the cache, such as histories and
+// mappings that never expire. Values are JSON documents, each saved to a file
+// of its own under the user data directory, so a change only rewrites the
+// key it touches.
+type Store struct {
+	data   map[string]json.RawMessage
+	mu     *sync.Mutex
+	dir    string
+	config StoreConfig
+}
+
+type StoreConfig struct {
+	ProjectName string // Name of the store
+	StorePath   string // Optional custom path override
+}
+
+func NewStore(config StoreConfig) *Store {
+	if config.ProjectName == "" {
+		config.ProjectName = "unnamed-project"
+	}
+
+	s := &Store{
+		data:   make(map[string]json.RawMessage),
+		mu:     &sync.Mutex{},
+		config: config,
+	}
+
+	s.dir = getStoreDir(config)
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		log.Printf("Warning: Store directory creation failed: %v. Continuing with in-memory store only\n", err)
+	}
+
+	if err := s.Load(); err != nil {
+		log.Printf("Error loading store: %v\n", err)
+	}
+
+	return s
+}
+
+// Get decodes the value saved under key into v. It reports false when there
+// is no such key.
+func (s *Store) Get(key string, v interface{}) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	raw, ok := s.data[key]
+	if !ok {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(raw, v); err != nil {
+		return true, fmt.Errorf("decoding %s: %w", key, err)
+	}
+	return true, nil
+}
+
+func (s *Store) Put(key string, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", key, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data[key] = raw
+	return s.save(key)
+}
+
+// Update runs fn on the current value of key and saves the result, holding
+// the lock for the whole read-modify-write. v must be a pointer and keeps its
+// zero value when the key doesn't exist yet.
+func (s *Store) Update(key string, v interface{}, fn func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if raw, ok := s.data[key]; ok {
+		if err := json.Unmarshal(raw, v); err != nil {
+			return fmt.Errorf("decoding %s: %w", key, err)
+		}
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", key, err)
+	}
+
+	s.data[key] = raw
+	return s.save(key)
+}
+
+func (s *Store) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.data[key]; !ok {
+		return nil
+	}
+
+	delete(s.data, key)
+	if err := os.Remove(s.keyPath(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Keys returns the sorted keys starting with prefix.
+func (s *Store) Keys(prefix string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := []string{}
+	for key := range s.data {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Load reads every key of the store directory.
+func (s *Store) Load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		key, err := url.QueryUnescape(name)
+		if err != nil {
+			continue
+		}
+
+		raw, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		if !json.Valid(raw) {
+			log.Printf("Warning: skipping corrupt store key %s", key)
+			continue
+		}
+		s.data[key] = raw
+	}
+
+	return nil
+}
+
+// save writes a key to a temporary file first so a crash never leaves it
+// half written. The caller must hold the lock.
+func (s *Store) save(key string) error {
+	path := s.keyPath(key)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, s.data[key], 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// keyPath escapes the key so separators such as ":" or "/" are safe in file
+// names on every platform.
+func (s *Store) keyPath(key string) string {
+	return filepath.Join(s.dir, url.QueryEscape(key)+".json")
+}
+
+func getStoreDir(config StoreConfig) string {
+	basePath := config.StorePath
+	if basePath == "" {
+		if path, err := os.UserConfigDir(); err == nil {
+			basePath = path
+		} else if path, err := os.UserHomeDir(); err == nil {
+			basePath = path
+		} else {
+			basePath = "."
+		}
+	}
+
+	return filepath.Join(basePath, config.ProjectName+"-data", "store")
+}
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"os"
+	"testing"
+)
+
+func TestPutGet(t *testing.T) {
+	s := NewStore(StoreConfig{
+		StorePath: t.TempDir(),
+	})
+
+	if err := s.Put("key1", []int{1, 2, 3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var val []int
+	ok, err := s.Get("key1", &val)
+	if err != nil || !ok {
+		t.Fatalf("expected to find key, got ok=%v err=%v", ok, err)
+	}
+	if len(val) != 3 || val[2] != 3 {
+		t.Errorf("expected to find value, got %v", val)
+	}
+
+	ok, _ = s.Get("missing", &val)
+	if ok {
+		t.Errorf("expected to not find key")
+	}
+}
+
+func TestUpdateAndReload(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStore(StoreConfig{
+		StorePath: dir,
+	})
+
+	for i := 0; i < 3; i++ {
+		var counter int
+		err := s.Update("counter", &counter, func() error {
+			counter++
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	reloaded := NewStore(StoreConfig{
+		StorePath: dir,
+	})
+
+	var counter int
+	if ok, err := reloaded.Get("counter", &counter); !ok || err != nil {
+		t.Fatalf("expected to find key after reload, got ok=%v err=%v", ok, err)
+	}
+	if counter != 3 {
+		t.Errorf("expected 3, got %d", counter)
+	}
+
+	if keys := reloaded.Keys("count"); len(keys) != 1 {
+		t.Errorf("expected 1 key with prefix, got %v", keys)
+	}
+}
+
+func TestKeyFiles(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStore(StoreConfig{
+		StorePath: dir,
+	})
+
+	if err := s.Put("players:620", []int{1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Put("nameid:730:AK-47 | Redline (Field-Tested)", 42); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("expected a file per key, got %v, %v", entries, err)
+	}
+
+	if err := s.Delete("players:620"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reloaded := NewStore(StoreConfig{
+		StorePath: dir,
+	})
+	if keys := reloaded.Keys(""); len(keys) != 1 || keys[0] != "nameid:730:AK-47 | Redline (Field-Tested)" {
+		t.Errorf("expected only the remaining key after reload, got %v", keys)
+	}
+}
@@ -1,19 +1,44 @@
 package main
 
 import (
+	"flag"
 	"log"
 
 	"github.com/masintxi/gamehub/internal/auth"
 	"github.com/masintxi/gamehub/internal/client"
 	"github.com/masintxi/gamehub/internal/config"
+	"github.com/masintxi/gamehub/internal/fakesteam"
 	"github.com/masintxi/gamehub/internal/server"
 )
 
 func main() {
-	cfg := config.Load()
+	demo := flag.Bool("demo", false, "run against a built-in fake Steam with demo users")
+	seedPath := flag.String("seed", "", "seed file of the fake Steam in demo mode")
+	flag.Parse()
 
-	client := client.NewClient(cfg.CacheConfig)
+	cfg := config.Load(*demo)
 
+	// In demo mode every Steam request, and the login, goes to the fake Steam
+	if *demo {
+		seed, err := loadSeed(*seedPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fake := fakesteam.New(seed)
+		if err := fake.Start("127.0.0.1:0"); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Demo mode: fake Steam running at %s", fake.URL)
+
+		cfg.TransportConfig.UpstreamURL = fake.URL
+		cfg.SteamAuth.LoginEndpoint = fake.URL + "/openid/login"
+	}
+
+	client := client.NewClient(cfg.CacheConfig, cfg.StoreConfig, cfg.CatalogConfig, cfg.TransportConfig)
+
+	// Steam login and the market session share the client transport and jar
+	cfg.SteamAuth.SteamClient = client.HttpClient
+	cfg.SteamAuth.Jar = client.Jar
 	steamAuth := auth.NewSteamAuth(cfg.SteamAuth)
 
 	server := server.NewServer(client, steamAuth, &cfg.Server)
@@ -23,6 +48,13 @@ func main() {
 	}
 }
 
+func loadSeed(path string) (*fakesteam.Seed, error) {
+	if path == "" {
+		return fakesteam.DefaultSeed()
+	}
+	return fakesteam.LoadSeed(path)
+}
+
 // fmt.Println("Starting server on :8080")
 // log.Fatal(http.ListenAndServe(":8080", r))
 